@@ -22,20 +22,35 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/tjgurwara99/citk/internal/golang"
+	"github.com/spf13/viper"
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/git"
+	"github.com/tjgurwara99/citk/internal/inspect"
+	"github.com/tjgurwara99/citk/internal/report"
 )
 
 // checkCmd represents the check command
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "A subcommand to run all CI checks with",
-	Long:  `A subcommand to run all CI checks with.`,
+	Long: `A subcommand to run all CI checks with.
+
+Exits with status 1 when annotations at or above the --fail-on severity are
+emitted and with status 2 when the checks could not be run at all.`,
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		language, err := cmd.Flags().GetString("language")
+		names, err := cmd.Flags().GetStringSlice("language")
+		if err != nil {
+			return err
+		}
+		langs, err := parseLanguages(names)
 		if err != nil {
 			return err
 		}
@@ -47,22 +62,252 @@ var checkCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		switch language {
-		case "golang", "go":
-			annotations, err := golang.Inspect(wd, branch)
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		scopeFlag, err := cmd.Flags().GetString("scope")
+		if err != nil {
+			return err
+		}
+		scope, err := git.ParseScope(scopeFlag)
+		if err != nil {
+			return err
+		}
+		verbose, err := cmd.Flags().GetBool("verbose")
+		if err != nil {
+			return err
+		}
+		stepSummary, err := cmd.Flags().GetBool("step-summary")
+		if err != nil {
+			return err
+		}
+		stdout := cmd.OutOrStdout()
+		f, ok := stdout.(*os.File)
+		terminal := ok && isTerminal(f)
+		if format == "" {
+			format = report.DetectReporter(os.Getenv, terminal)
+		}
+		reporter, err := lookupReporter(format)
+		if err != nil {
+			return err
+		}
+		values, err := cmd.Flags().GetStringSlice("reporter")
+		if err != nil {
+			return err
+		}
+		extra, err := parseReporters(values)
+		if err != nil {
+			return err
+		}
+		apiURL, err := cmd.Flags().GetString("github-api-url")
+		if err != nil {
+			return err
+		}
+		failOn, err := parseFailOn(viper.GetString("fail-on"))
+		if err != nil {
+			return err
+		}
+		if err := loadRules(); err != nil {
+			return err
+		}
+		changes, err := git.ListChanges(wd, branch, scope)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve changes from git: %w", err)
+		}
+		if len(langs) == 0 {
+			langs = detectLanguages(changes.Paths())
+		}
+		if verbose {
+			logChanges(cmd.ErrOrStderr(), branch, changes, langs)
+		}
+		var (
+			rules  []inspect.Rule
+			result inspect.Result
+		)
+		for _, lang := range langs {
+			langRules := inspect.LanguageRules(lang.Name)
+			langResult, err := inspect.Run(wd, changes, lang, langRules)
 			if err != nil {
 				return err
 			}
-			for _, annotation := range annotations {
-				fmt.Println(annotation)
+			rules = append(rules, langRules...)
+			result.Add(langResult)
+		}
+		rules = append(rules, inspect.LanguageRules("")...)
+		run := report.Run{
+			Rules:        rules,
+			Result:       result,
+			Dir:          wd,
+			Base:         changes.Base,
+			Head:         changes.Head,
+			Version:      toolVersion(),
+			Color:        terminal && os.Getenv("NO_COLOR") == "",
+			GitHubAPIURL: apiURL,
+		}
+		if err := reporter.Report(stdout, run); err != nil {
+			return err
+		}
+		if stepSummary {
+			if err := writeStepSummary(changes, result); err != nil {
+				return err
+			}
+		}
+		for _, r := range extra {
+			if err := r.report(cmd.ErrOrStderr(), run); err != nil {
+				return err
 			}
 		}
-		return nil
+		return checkViolations(failOn, result.Annotations)
 	},
 }
 
+// violationsError reports that check emitted annotations at or above the
+// --fail-on severity.
+type violationsError struct {
+	count     int
+	threshold annotation.AnnotationType
+}
+
+func (e violationsError) Error() string {
+	return fmt.Sprintf("found %d annotation(s) at or above %s severity", e.count, e.threshold)
+}
+
+// parseFailOn parses the --fail-on value. An empty AnnotationType means check
+// never fails because of annotations.
+func parseFailOn(s string) (annotation.AnnotationType, error) {
+	switch s {
+	case "never":
+		return "", nil
+	case string(annotation.Error), string(annotation.Warning), string(annotation.Notice):
+		return annotation.AnnotationType(s), nil
+	default:
+		return "", fmt.Errorf("unknown --fail-on value %q, expected one of error, warning, notice or never", s)
+	}
+}
+
+// checkViolations returns a violationsError when any annotation is at least
+// as severe as threshold.
+func checkViolations(threshold annotation.AnnotationType, annotations []annotation.Annotation) error {
+	if threshold == "" || annotation.MaxSeverity(annotations).Severity() < threshold.Severity() {
+		return nil
+	}
+	count := 0
+	for _, a := range annotations {
+		if a.Type.Severity() >= threshold.Severity() {
+			count++
+		}
+	}
+	return violationsError{count: count, threshold: threshold}
+}
+
+// logChanges describes which commits were compared, how many files are in
+// scope and which languages they are inspected as.
+func logChanges(w io.Writer, branch string, changes git.Changes, langs []inspect.Language) {
+	if changes.Base == "" {
+		fmt.Fprintf(w, "Inspecting every file tracked at HEAD %s\n", changes.Head)
+	} else {
+		fmt.Fprintf(w, "Comparing HEAD %s against merge base %s with %s\n", changes.Head, changes.Base, branch)
+	}
+	fmt.Fprintf(w, "%d file(s) in scope\n", len(changes.Files))
+	fmt.Fprintf(w, "Inspecting languages: %s\n", strings.Join(languageNames(langs), ", "))
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// lookupReporter resolves the name of a reporter.
+func lookupReporter(name string) (report.Reporter, error) {
+	reporter, ok := report.LookupReporter(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown reporter %q, expected one of %s", name, strings.Join(report.ReporterNames(), ", "))
+	}
+	return reporter, nil
+}
+
+// serviceReporters are the reporters that send the findings to a service
+// and only describe what they did on their writer.
+var serviceReporters = map[string]bool{
+	"github-check":     true,
+	"github-pr-review": true,
+}
+
+// extraReporter is a reporter named by --reporter.
+type extraReporter struct {
+	name     string
+	reporter report.Reporter
+	// path is the file the report is written to, empty for the service
+	// reporters that describe what they did on stderr.
+	path string
+}
+
+// report runs the reporter, writing to its file or, without one, to stderr.
+func (r extraReporter) report(stderr io.Writer, run report.Run) error {
+	if r.path == "" {
+		return r.reporter.Report(stderr, run)
+	}
+	f, err := os.Create(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s report: %w", r.name, err)
+	}
+	defer f.Close()
+	run.Color = false
+	if err := r.reporter.Report(f, run); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// parseReporters parses the --reporter values: service reporters like
+// github-check by name and formats like sarif as name=path, naming the file
+// the report is written to.
+func parseReporters(values []string) ([]extraReporter, error) {
+	reporters := make([]extraReporter, 0, len(values))
+	for _, value := range values {
+		name, path, _ := strings.Cut(value, "=")
+		reporter, err := lookupReporter(name)
+		if err != nil {
+			return nil, err
+		}
+		if path == "" && !serviceReporters[name] {
+			return nil, fmt.Errorf("reporter %s writes a report, pass it as %s=<path>", name, name)
+		}
+		reporters = append(reporters, extraReporter{name: name, reporter: reporter, path: path})
+	}
+	return reporters, nil
+}
+
+// writeStepSummary appends a Markdown report of result to the GitHub Actions
+// job summary, linking findings to the inspected commit.
+func writeStepSummary(changes git.Changes, result inspect.Result) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return errors.New("--step-summary requires GITHUB_STEP_SUMMARY to be set")
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open job summary: %w", err)
+	}
+	defer f.Close()
+	if err := report.Summary(f, report.GitHubBlobURL(changes.Head), result); err != nil {
+		return fmt.Errorf("failed to write job summary: %w", err)
+	}
+	return f.Close()
+}
+
 func init() {
 	rootCmd.AddCommand(checkCmd)
-	checkCmd.Flags().StringP("language", "l", "", "Language to run the check against")
-	checkCmd.Flags().StringP("branch", "b", "main", "branch to compare the current HEAD against")
+	checkCmd.Flags().StringSliceP("language", "l", nil, "Comma separated languages to run the check against, any of: go, python, rust, typescript, javascript (detected from the changed files by default)")
+	checkCmd.Flags().StringP("branch", "b", "main", "revision whose merge base with HEAD is compared against: a branch, remote branch, tag, SHA or expression like HEAD~3 (falls back to origin/<branch>)")
+	checkCmd.Flags().BoolP("verbose", "v", false, "Print details about the commits being compared to stderr")
+	checkCmd.Flags().String("scope", string(git.ScopeLines), "Which findings to report, one of: lines (changed lines only), files (whole changed files), all (every tracked file)")
+	checkCmd.Flags().StringP("format", "f", "", "Output format, one of: pretty (for terminals), github, azure, teamcity, buildkite, sarif, gitlab (Code Quality report), checkstyle, junit, json, jsonl, rdjson, rdjsonl (reviewdog); detected from the CI environment by default, otherwise pretty on a terminal and github elsewhere")
+	checkCmd.Flags().Bool("step-summary", false, "Append a Markdown report to the GitHub Actions job summary named by $GITHUB_STEP_SUMMARY")
+	checkCmd.Flags().StringSlice("reporter", nil, "Comma separated reporters to also send the findings to: github-pr-review (a review with inline comments) or github-check (a check run with every annotation), both using GITHUB_TOKEN, or any --format value as format=path to also write that report to a file")
+	checkCmd.Flags().String("github-api-url", "", "Base URL of the GitHub REST API (default $GITHUB_API_URL or https://api.github.com)")
+	checkCmd.Flags().String("fail-on", string(annotation.Error), "Lowest annotation severity that makes check exit non-zero, one of: error, warning, notice, never")
+	cobra.CheckErr(viper.BindPFlag("fail-on", checkCmd.Flags().Lookup("fail-on")))
 }
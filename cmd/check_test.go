@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+	"github.com/tjgurwara99/citk/internal/report"
+)
+
+func TestParseFailOn(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected annotation.AnnotationType
+		err      bool
+	}{
+		{value: "error", expected: annotation.Error},
+		{value: "warning", expected: annotation.Warning},
+		{value: "notice", expected: annotation.Notice},
+		{value: "never", expected: ""},
+		{value: "debug", err: true},
+		{value: "Error", err: true},
+		{value: "", err: true},
+	}
+	for _, tc := range tests {
+		returned, err := parseFailOn(tc.value)
+		if tc.err {
+			if err == nil {
+				t.Errorf("expected an error for %q", tc.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("returned an error for %q: %s", tc.value, err)
+		}
+		if returned != tc.expected {
+			t.Errorf("expected and returned values do not match: expected %+v, returned %+v", tc.expected, returned)
+		}
+	}
+}
+
+func TestCheckViolations(t *testing.T) {
+	annotations := []annotation.Annotation{
+		{Message: "a", Type: annotation.Error},
+		{Message: "b", Type: annotation.Warning},
+		{Message: "c", Type: annotation.Warning},
+		{Message: "d", Type: annotation.Notice},
+		{Message: "e", Type: annotation.Debug},
+	}
+	tests := []struct {
+		threshold   annotation.AnnotationType
+		annotations []annotation.Annotation
+		expected    error
+	}{
+		{threshold: annotation.Error, annotations: annotations, expected: violationsError{count: 1, threshold: annotation.Error}},
+		{threshold: annotation.Warning, annotations: annotations, expected: violationsError{count: 3, threshold: annotation.Warning}},
+		{threshold: annotation.Notice, annotations: annotations, expected: violationsError{count: 4, threshold: annotation.Notice}},
+		{threshold: annotation.Error, annotations: annotations[1:]},
+		{threshold: "", annotations: annotations},
+		{threshold: annotation.Notice, annotations: nil},
+	}
+	for _, tc := range tests {
+		returned := checkViolations(tc.threshold, tc.annotations)
+		if !reflect.DeepEqual(tc.expected, returned) {
+			t.Errorf("expected and returned values do not match: expected %+v, returned %+v", tc.expected, returned)
+		}
+		// Execute tells violations from failures by the error type
+		var violations violationsError
+		if returned != nil && !errors.As(returned, &violations) {
+			t.Errorf("expected a violationsError, returned %T", returned)
+		}
+	}
+}
+
+func TestParseReporters(t *testing.T) {
+	reporters, err := parseReporters([]string{"github-check", "sarif=out/citk.sarif"})
+	if err != nil {
+		t.Fatalf("returned an error: %s", err)
+	}
+	if len(reporters) != 2 || reporters[0].path != "" || reporters[1].path != "out/citk.sarif" {
+		t.Errorf("expected github-check without and sarif with a path, returned %+v", reporters)
+	}
+
+	for _, values := range [][]string{{"sarif"}, {"cobol=out.txt"}} {
+		if _, err := parseReporters(values); err == nil {
+			t.Errorf("expected an error for %q", values)
+		}
+	}
+}
+
+func TestExtraReporterWritesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "citk.txt")
+	reporters, err := parseReporters([]string{"github=" + path})
+	if err != nil {
+		t.Fatalf("returned an error: %s", err)
+	}
+	run := report.Run{Result: inspect.Result{Annotations: []annotation.Annotation{
+		{FileName: "a.go", StartLine: 3, Message: "bad_var is bad", Type: annotation.Error},
+	}}}
+	var stderr bytes.Buffer
+	if err := reporters[0].report(&stderr, run); err != nil {
+		t.Fatalf("returned an error: %s", err)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the report: %s", err)
+	}
+	expected := "::error file=a.go,line=3::bad_var is bad\n"
+	if string(written) != expected {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, string(written))
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("expected nothing on stderr, returned %q", stderr.String())
+	}
+}
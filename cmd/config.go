@@ -0,0 +1,123 @@
+/*
+Copyright © 2023 Taj Singh <tjgurwara99@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+	"github.com/tjgurwara99/citk/internal/golang"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// configKeys are the top-level keys the config file may contain.
+var configKeys = map[string]bool{
+	"fail-on":      true,
+	"custom-rules": true,
+	"rules":        true,
+}
+
+// loadRules registers the custom rules declared in the config file and then
+// applies the rules section to every registered rule.
+func loadRules() error {
+	if err := validateConfigKeys(); err != nil {
+		return err
+	}
+	if err := registerCustomRules(); err != nil {
+		return err
+	}
+	return configureRules()
+}
+
+// validateConfigKeys reports the top-level keys of the config file that citk
+// doesn't know about, which are most likely typos.
+func validateConfigKeys() error {
+	if viper.ConfigFileUsed() == "" {
+		return nil
+	}
+	// viper.AllKeys also contains flags and environment variables, so the
+	// file is read on its own.
+	v := viper.New()
+	v.SetConfigFile(viper.ConfigFileUsed())
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var unknown []string
+	for key := range v.AllSettings() {
+		if !configKeys[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("invalid config file %s: unknown keys %q", viper.ConfigFileUsed(), unknown)
+}
+
+// registerCustomRules compiles the custom-rules declared in the config file
+// and adds them to the rule registry.
+func registerCustomRules() error {
+	var configs []inspect.CustomRule
+	err := viper.UnmarshalKey("custom-rules", &configs, func(c *mapstructure.DecoderConfig) {
+		c.ErrorUnused = true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read custom-rules from config: %w", err)
+	}
+	for _, config := range configs {
+		language := config.Language
+		if language == "" {
+			language = golang.Language.Name
+		}
+		lang, ok := lookupLanguage(language)
+		if !ok {
+			return fmt.Errorf("custom rule %s targets unsupported language %q", config.ID, config.Language)
+		}
+		rule, err := config.Compile(lang)
+		if err != nil {
+			return err
+		}
+		if err := inspect.Register(rule); err != nil {
+			return fmt.Errorf("failed to register custom rule: %w", err)
+		}
+	}
+	return nil
+}
+
+// configureRules applies the rules section of the config file.
+func configureRules() error {
+	var configs map[string]inspect.RuleConfig
+	err := viper.UnmarshalKey("rules", &configs, func(c *mapstructure.DecoderConfig) {
+		c.ErrorUnused = true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read rules from config: %w", err)
+	}
+	if err := inspect.Configure(configs); err != nil {
+		return fmt.Errorf("invalid rules in config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// chdir changes the working directory to dir until the test ends.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %s", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to change working directory: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatalf("failed to restore working directory: %s", err)
+		}
+	})
+}
+
+// writeConfig writes a .citk.yaml with content to dir.
+func writeConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, ".citk.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %s", err)
+	}
+	return path
+}
+
+func TestInitConfigSearchesParents(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Reset()
+	t.Setenv("HOME", t.TempDir())
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to resolve temp dir: %s", err)
+	}
+	expected := writeConfig(t, root, "fail-on: warning\n")
+	dir := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create %s: %s", dir, err)
+	}
+	chdir(t, dir)
+
+	initConfig()
+	if returned := viper.ConfigFileUsed(); expected != returned {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+	if returned := viper.GetString("fail-on"); returned != "warning" {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", "warning", returned)
+	}
+}
+
+func TestValidateConfigKeys(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+
+	viper.Reset()
+	viper.SetConfigFile(writeConfig(t, dir, "fail-on: warning\nrules: {}\n"))
+	if err := validateConfigKeys(); err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	viper.Reset()
+	viper.SetConfigFile(writeConfig(t, dir, "fail-on: warning\nfial-on: error\ncustom_rules: []\n"))
+	err := validateConfigKeys()
+	if err == nil || !strings.Contains(err.Error(), `["custom_rules" "fial-on"]`) {
+		t.Errorf("expected an error listing the unknown keys, returned %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2023 Taj Singh <tjgurwara99@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tjgurwara99/citk/internal/golang"
+	"github.com/tjgurwara99/citk/internal/inspect"
+	"github.com/tjgurwara99/citk/internal/python"
+	"github.com/tjgurwara99/citk/internal/rust"
+	"github.com/tjgurwara99/citk/internal/typescript"
+)
+
+// languages lists the languages citk can inspect, in the order they are
+// run.
+var languages = []inspect.Language{
+	golang.Language,
+	python.Language,
+	rust.Language,
+	typescript.TypeScript,
+	typescript.JavaScript,
+}
+
+// languageAliases maps the short names accepted by --language and the
+// language key of custom rules to language names.
+var languageAliases = map[string]string{
+	"golang": golang.Language.Name,
+	"py":     python.Language.Name,
+	"rs":     rust.Language.Name,
+	"ts":     typescript.TypeScript.Name,
+	"js":     typescript.JavaScript.Name,
+}
+
+// lookupLanguage returns the language called name or one of its aliases.
+func lookupLanguage(name string) (inspect.Language, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := languageAliases[name]; ok {
+		name = alias
+	}
+	for _, lang := range languages {
+		if lang.Name == name {
+			return lang, true
+		}
+	}
+	return inspect.Language{}, false
+}
+
+// parseLanguages looks up the languages passed to --language, dropping
+// duplicates.
+func parseLanguages(names []string) ([]inspect.Language, error) {
+	var langs []inspect.Language
+	seen := map[string]bool{}
+	for _, name := range names {
+		lang, ok := lookupLanguage(name)
+		if !ok {
+			return nil, fmt.Errorf("unsupported language %q", name)
+		}
+		if !seen[lang.Name] {
+			seen[lang.Name] = true
+			langs = append(langs, lang)
+		}
+	}
+	return langs, nil
+}
+
+// detectLanguages returns the languages at least one of paths is written
+// in.
+func detectLanguages(paths []string) []inspect.Language {
+	var langs []inspect.Language
+	for _, lang := range languages {
+		for _, path := range paths {
+			if lang.Matches(path) {
+				langs = append(langs, lang)
+				break
+			}
+		}
+	}
+	return langs
+}
+
+// languageNames returns the names of langs.
+func languageNames(langs []inspect.Language) []string {
+	names := make([]string, 0, len(langs))
+	for _, lang := range langs {
+		names = append(names, lang.Name)
+	}
+	return names
+}
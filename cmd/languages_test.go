@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLanguages(t *testing.T) {
+	langs, err := parseLanguages([]string{"go", "py", "golang", "TS"})
+	if err != nil {
+		t.Fatalf("returned an error: %s", err)
+	}
+	expected := []string{"go", "python", "typescript"}
+	if names := languageNames(langs); !reflect.DeepEqual(expected, names) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, names)
+	}
+
+	if _, err := parseLanguages([]string{"go", "cobol"}); err == nil {
+		t.Errorf("expected an error for an unsupported language")
+	}
+}
+
+func TestDetectLanguages(t *testing.T) {
+	paths := []string{"README.md", "web/src/App.tsx", "cmd/root.go", "internal/git/git.go", "scripts/build.py"}
+	expected := []string{"go", "python", "typescript"}
+	if names := languageNames(detectLanguages(paths)); !reflect.DeepEqual(expected, names) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, names)
+	}
+}
@@ -22,8 +22,12 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -31,6 +35,22 @@ import (
 
 var cfgFile string
 
+// version is the version of citk, set by release builds with
+// -ldflags "-X github.com/tjgurwara99/citk/cmd.version=v1.2.3".
+var version string
+
+// toolVersion returns version, falling back to the module version of
+// binaries built with go install and to "devel" otherwise.
+func toolVersion() string {
+	if version != "" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "devel"
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "citk",
@@ -41,23 +61,34 @@ var rootCmd = &cobra.Command{
 	// Run: func(cmd *cobra.Command, args []string) { },
 }
 
+// Exit statuses distinguishing convention violations from tool failures.
+const (
+	exitViolations = 1
+	exitFailure    = 2
+)
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	err := rootCmd.Execute()
+	var violations violationsError
+	if errors.As(err, &violations) {
+		os.Exit(exitViolations)
+	}
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitFailure)
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.Version = toolVersion()
 
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.citk.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is the first .citk.yaml in the working directory, its parents or $HOME)")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -70,20 +101,38 @@ func initConfig() {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
 	} else {
-		// Find home directory.
+		// Search config with name ".citk" (without extension) in the working
+		// directory and each of its parents, so repositories can carry their
+		// own config.
+		wd, err := os.Getwd()
+		cobra.CheckErr(err)
+		for dir := wd; ; dir = filepath.Dir(dir) {
+			viper.AddConfigPath(dir)
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+
+		// Fall back to the home directory.
 		home, err := os.UserHomeDir()
 		cobra.CheckErr(err)
-
-		// Search config in home directory with name ".citk" (without extension).
 		viper.AddConfigPath(home)
 		viper.SetConfigType("yaml")
 		viper.SetConfigName(".citk")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	viper.SetEnvPrefix("citk")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv() // read in environment variables that match, e.g. CITK_FAIL_ON
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
+	err := viper.ReadInConfig()
+	var notFound viper.ConfigFileNotFoundError
+	switch {
+	case err == nil:
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	case !errors.As(err, &notFound):
+		fmt.Fprintln(os.Stderr, "Error: failed to read config file:", err)
+		os.Exit(exitFailure)
 	}
 }
@@ -0,0 +1,120 @@
+/*
+Copyright © 2023 Taj Singh <tjgurwara99@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// rulesCmd represents the rules command
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List and explain the rules citk checks",
+	Long:  `List and explain the rules citk checks, including the custom rules declared in the config file.`,
+}
+
+// rulesListCmd represents the rules list command
+var rulesListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List every rule",
+	Long:         `List every rule with its ID, language, category and default severity.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadRules(); err != nil {
+			return err
+		}
+		return listRules(cmd.OutOrStdout(), inspect.Rules())
+	},
+}
+
+// rulesExplainCmd represents the rules explain command
+var rulesExplainCmd = &cobra.Command{
+	Use:          "explain <id>",
+	Short:        "Explain a rule",
+	Long:         `Explain what a rule checks and show examples of code breaking and following it.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadRules(); err != nil {
+			return err
+		}
+		rule, ok := inspect.Lookup(args[0])
+		if !ok {
+			return fmt.Errorf("unknown rule %q, run `citk rules list` to see every rule", args[0])
+		}
+		explainRule(cmd.OutOrStdout(), rule)
+		return nil
+	},
+}
+
+func listRules(w io.Writer, rules []inspect.Rule) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tLANGUAGE\tCATEGORY\tSEVERITY\tENABLED\tTITLE")
+	for _, rule := range rules {
+		language := rule.Language
+		if language == "" {
+			language = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%s\n", rule.ID, language, rule.Category, rule.Severity, !rule.Disabled, rule.Title)
+	}
+	return tw.Flush()
+}
+
+func explainRule(w io.Writer, rule inspect.Rule) {
+	fmt.Fprintf(w, "%s: %s\n\n", rule.ID, rule.Title)
+	if rule.Language != "" {
+		fmt.Fprintf(w, "Language: %s\n", rule.Language)
+	}
+	fmt.Fprintf(w, "Category: %s\n", rule.Category)
+	fmt.Fprintf(w, "Severity: %s\n", rule.Severity)
+	fmt.Fprintf(w, "Enabled: %t\n", !rule.Disabled)
+	if rule.Description != "" {
+		fmt.Fprintf(w, "\n%s\n", rule.Description)
+	}
+	if len(rule.Options) > 0 {
+		fmt.Fprintf(w, "\nOptions:\n")
+		for _, option := range rule.Options {
+			fmt.Fprintf(w, "    %s: %s (default %v)\n", option.Name, option.Description, option.Default)
+		}
+	}
+	for _, example := range rule.Examples {
+		fmt.Fprintf(w, "\nBad:\n%s\n", indent(example.Bad))
+		fmt.Fprintf(w, "\nGood:\n%s\n", indent(example.Good))
+	}
+}
+
+func indent(code string) string {
+	return "    " + strings.ReplaceAll(code, "\n", "\n    ")
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesListCmd)
+	rulesCmd.AddCommand(rulesExplainCmd)
+}
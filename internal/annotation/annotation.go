@@ -1,7 +1,10 @@
 package annotation
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"path/filepath"
 	"strings"
 )
 
@@ -14,11 +17,54 @@ const (
 	Debug   AnnotationType = "debug"
 )
 
+// ParseAnnotationType validates s and returns it as an AnnotationType.
+func ParseAnnotationType(s string) (AnnotationType, error) {
+	switch t := AnnotationType(s); t {
+	case Notice, Warning, Error, Debug:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unknown annotation type %q, expected one of %s, %s, %s or %s", s, Error, Warning, Notice, Debug)
+	}
+}
+
+// Severity ranks annotation types so they can be compared, with Error being
+// the most severe. Unknown types rank below Debug.
+func (t AnnotationType) Severity() int {
+	switch t {
+	case Debug:
+		return 1
+	case Notice:
+		return 2
+	case Warning:
+		return 3
+	case Error:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// MaxSeverity returns the most severe type among annotations, or an empty
+// AnnotationType when there are none.
+func MaxSeverity(annotations []Annotation) AnnotationType {
+	var max AnnotationType
+	for _, a := range annotations {
+		if a.Type.Severity() > max.Severity() {
+			max = a.Type
+		}
+	}
+	return max
+}
+
 type Annotation struct {
 	FileName string
-	Title    string
-	Message  string
-	// lines are of type uint32 because tree-sitter uses this for StartPoint().Row
+	// RuleID is the ID of the rule that produced the annotation.
+	RuleID  string
+	Title   string
+	Message string
+	// Lines and columns are 1-based, with 0 meaning unknown. Columns count
+	// Unicode code points and EndCol is exclusive. They are uint32 because
+	// tree-sitter uses it for StartPoint().Row.
 	StartLine uint32
 	EndLine   uint32
 	StartCol  uint32
@@ -26,26 +72,93 @@ type Annotation struct {
 	Type      AnnotationType
 }
 
-func (a Annotation) String() string {
-	builder := strings.Builder{}
-	builder.WriteString(fmt.Sprintf("::%s ", a.Type))
-	if a.FileName != "" {
-		builder.WriteString(fmt.Sprintf("file=%s,", a.FileName))
-	}
-	if a.StartLine != 0 {
-		builder.WriteString(fmt.Sprintf("line=%d,", a.StartLine))
-	}
-	if a.EndLine != 0 {
-		builder.WriteString(fmt.Sprintf("endLine=%d,", a.EndLine))
+// Fingerprint identifies the finding behind the annotation across runs. It
+// leaves out the position, so a finding keeps its fingerprint when code
+// above it moves it to another line.
+func (a Annotation) Fingerprint() string {
+	sum := sha256.Sum256([]byte(filepath.ToSlash(a.FileName) + "\x00" + a.RuleID + "\x00" + a.Message))
+	return hex.EncodeToString(sum[:16])
+}
+
+// UniqueFingerprints returns the fingerprint of each annotation, re-hashed
+// with the occurrence index for repeated fingerprints, like the same
+// misnamed variable in two functions of a file, so every finding gets a
+// fingerprint of its own.
+func UniqueFingerprints(annotations []Annotation) []string {
+	fingerprints := make([]string, 0, len(annotations))
+	seen := map[string]int{}
+	for _, a := range annotations {
+		fingerprint := a.Fingerprint()
+		seen[fingerprint]++
+		if n := seen[fingerprint]; n > 1 {
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%s#%d", fingerprint, n)))
+			fingerprint = hex.EncodeToString(sum[:16])
+		}
+		fingerprints = append(fingerprints, fingerprint)
 	}
-	if a.StartCol != 0 {
-		builder.WriteString(fmt.Sprintf("col=%d,", a.StartCol))
+	return fingerprints
+}
+
+// InclusiveEndCol returns the column of the last character of the
+// annotation, the way GitHub expects end columns, or 0 when EndCol is
+// unknown.
+func (a Annotation) InclusiveEndCol() uint32 {
+	if a.EndCol <= a.StartCol {
+		return 0
 	}
-	if a.EndCol != 0 {
-		builder.WriteString(fmt.Sprintf("endCol=%d", a.EndCol))
+	return a.EndCol - 1
+}
+
+// String formats the annotation as a GitHub Actions workflow command, for
+// example:
+//
+//	::error file=main.go,line=3,endLine=3,col=6,endCol=9,title=Bad name::Rename it
+//
+// Debug annotations are written as ::debug:: commands, which take no
+// properties.
+func (a Annotation) String() string {
+	var props []string
+	if a.Type != Debug {
+		if a.FileName != "" {
+			props = append(props, "file="+escapeProperty(a.FileName))
+		}
+		if a.StartLine != 0 {
+			props = append(props, fmt.Sprintf("line=%d", a.StartLine))
+		}
+		if a.EndLine != 0 {
+			props = append(props, fmt.Sprintf("endLine=%d", a.EndLine))
+		}
+		if a.StartCol != 0 {
+			props = append(props, fmt.Sprintf("col=%d", a.StartCol))
+		}
+		if endCol := a.InclusiveEndCol(); endCol != 0 {
+			props = append(props, fmt.Sprintf("endCol=%d", endCol))
+		}
+		if a.Title != "" {
+			props = append(props, "title="+escapeProperty(a.Title))
+		}
 	}
-	if a.Message != "" {
-		builder.WriteString("::" + a.Message)
+	builder := strings.Builder{}
+	builder.WriteString("::" + string(a.Type))
+	if len(props) > 0 {
+		builder.WriteString(" " + strings.Join(props, ","))
 	}
+	builder.WriteString("::" + escapeData(a.Message))
 	return builder.String()
 }
+
+// dataEscaper and propertyEscaper percent-encode the characters that would
+// end a workflow command message or property early, following the escaping
+// of the @actions/core toolkit.
+var (
+	dataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	propertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func escapeData(s string) string {
+	return dataEscaper.Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return propertyEscaper.Replace(s)
+}
@@ -0,0 +1,142 @@
+package annotation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// parseCommand parses a workflow command the way the GitHub Actions runner
+// does, so String can be checked against the other side of the protocol.
+func parseCommand(line string) (Annotation, error) {
+	if !strings.HasPrefix(line, "::") {
+		return Annotation{}, fmt.Errorf("missing :: prefix in %q", line)
+	}
+	command, message, ok := strings.Cut(line[2:], "::")
+	if !ok {
+		return Annotation{}, fmt.Errorf("missing :: separator in %q", line)
+	}
+	if strings.ContainsAny(line, "\r\n") {
+		return Annotation{}, fmt.Errorf("command %q spans several lines", line)
+	}
+	name, props, _ := strings.Cut(command, " ")
+	a := Annotation{Type: AnnotationType(name), Message: unescaper.Replace(message)}
+	if props == "" {
+		return a, nil
+	}
+	for _, prop := range strings.Split(props, ",") {
+		key, value, ok := strings.Cut(prop, "=")
+		if !ok {
+			return Annotation{}, fmt.Errorf("malformed property %q", prop)
+		}
+		value = unescaper.Replace(value)
+		var err error
+		switch key {
+		case "file":
+			a.FileName = value
+		case "title":
+			a.Title = value
+		case "line":
+			a.StartLine, err = parseUint32(value)
+		case "endLine":
+			a.EndLine, err = parseUint32(value)
+		case "col":
+			a.StartCol, err = parseUint32(value)
+		case "endCol":
+			// the runner's endCol is inclusive
+			a.EndCol, err = parseUint32(value)
+			a.EndCol++
+		default:
+			err = fmt.Errorf("unknown property %q", key)
+		}
+		if err != nil {
+			return Annotation{}, err
+		}
+	}
+	return a, nil
+}
+
+var unescaper = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%3A", ":", "%2C", ",", "%25", "%")
+
+func parseUint32(s string) (uint32, error) {
+	n, err := strconv.ParseUint(s, 10, 32)
+	return uint32(n), err
+}
+
+func TestAnnotationString(t *testing.T) {
+	a := Annotation{
+		FileName:  "cmd/a,b.go",
+		Title:     "Bad name: snake_case",
+		Message:   "100% wrong\nrename it",
+		StartLine: 3,
+		EndLine:   3,
+		StartCol:  6,
+		EndCol:    10,
+		Type:      Error,
+	}
+	expected := "::error file=cmd/a%2Cb.go,line=3,endLine=3,col=6,endCol=9,title=Bad name%3A snake_case::100%25 wrong%0Arename it"
+	if returned := a.String(); expected != returned {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+}
+
+func TestAnnotationEndCol(t *testing.T) {
+	// snake_case_function in "func snake_case_function() {}" spans columns
+	// 6 to 24
+	a := Annotation{FileName: "main.go", StartLine: 1, EndLine: 1, StartCol: 6, EndCol: 25, Type: Error}
+	expected := "::error file=main.go,line=1,endLine=1,col=6,endCol=24::"
+	if returned := a.String(); expected != returned {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+}
+
+func TestAnnotationRoundTrip(t *testing.T) {
+	tests := []Annotation{
+		{FileName: "main.go", Title: "Bad func", Message: "rename it", StartLine: 1, EndLine: 1, StartCol: 6, EndCol: 9, Type: Error},
+		{FileName: "dir: odd, name/100%.go", Title: "a,b:c", Message: "line one\r\nline two: 50%, done", StartLine: 2, EndLine: 4, Type: Warning},
+		{Message: "no location", Type: Notice},
+		{FileName: "main.go", StartLine: 7, Type: Notice},
+		{Message: "debug: %0A stays literal", Type: Debug},
+	}
+	for _, expected := range tests {
+		returned, err := parseCommand(expected.String())
+		if err != nil {
+			t.Errorf("failed to parse %q: %s", expected.String(), err)
+			continue
+		}
+		if !reflect.DeepEqual(expected, returned) {
+			t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := Annotation{FileName: "main.go", RuleID: "go-func-naming", Message: "bad_func is bad", StartLine: 3, Type: Error}
+	moved := a
+	moved.StartLine, moved.EndLine = 10, 10
+	if a.Fingerprint() != moved.Fingerprint() {
+		t.Errorf("expected the fingerprint to ignore the position")
+	}
+	other := a
+	other.Message = "other_func is bad"
+	if a.Fingerprint() == other.Fingerprint() {
+		t.Errorf("expected different findings to have different fingerprints")
+	}
+}
+
+func TestUniqueFingerprints(t *testing.T) {
+	a := Annotation{FileName: "main.go", RuleID: "go-var-naming", Message: "bad_var is bad", StartLine: 3, Type: Error}
+	repeated := a
+	repeated.StartLine = 10
+	other := a
+	other.Message = "other_var is bad"
+	fingerprints := UniqueFingerprints([]Annotation{a, other, repeated})
+	if fingerprints[0] != a.Fingerprint() || fingerprints[1] != other.Fingerprint() {
+		t.Errorf("expected the first occurrences to keep their fingerprints, returned %q", fingerprints)
+	}
+	if fingerprints[2] == fingerprints[0] {
+		t.Errorf("expected repeated findings to have unique fingerprints, returned %q", fingerprints)
+	}
+}
@@ -1,40 +1,200 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
-func ListChangedFiles(srcDir string, relBranch string) ([]string, error) {
+// Scope controls how much of the repository is inspected.
+type Scope string
+
+const (
+	// ScopeLines only reports findings on lines changed relative to the base branch.
+	ScopeLines Scope = "lines"
+	// ScopeFiles reports findings anywhere in the changed files.
+	ScopeFiles Scope = "files"
+	// ScopeAll reports findings in every file tracked at HEAD.
+	ScopeAll Scope = "all"
+)
+
+// ParseScope validates s and returns it as a Scope.
+func ParseScope(s string) (Scope, error) {
+	switch scope := Scope(s); scope {
+	case ScopeLines, ScopeFiles, ScopeAll:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("unknown scope %q, expected one of %s, %s or %s", s, ScopeLines, ScopeFiles, ScopeAll)
+	}
+}
+
+// LineRange is an inclusive range of 1-based line numbers.
+type LineRange struct {
+	Start uint32
+	End   uint32
+}
+
+// Changes records the files, and the lines within them, that are in scope
+// for inspection.
+type Changes struct {
+	// Head is the SHA of the commit being inspected.
+	Head string
+	// Base is the SHA of the merge base HEAD was compared against. It is
+	// empty when every tracked file is in scope.
+	Base string
+	// Files maps slash separated paths relative to the repository root to
+	// the line ranges added or modified on HEAD. A nil slice means every
+	// line of the file is in scope.
+	Files map[string][]LineRange
+}
+
+// Paths returns the paths of all files in scope in lexical order.
+func (c Changes) Paths() []string {
+	paths := make([]string, 0, len(c.Files))
+	for path := range c.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Contains reports whether the given line of file is in scope.
+func (c Changes) Contains(file string, line uint32) bool {
+	ranges, ok := c.Files[file]
+	if !ok {
+		return false
+	}
+	if ranges == nil {
+		return true
+	}
+	for _, r := range ranges {
+		if r.Start <= line && line <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// ListChanges returns the files and lines of HEAD that are in scope when
+// compared against the base revision. Like `git diff base...HEAD`, HEAD is
+// compared against its merge base with base so commits that landed on base
+// after HEAD branched off aren't reported as changes.
+func ListChanges(srcDir string, base string, scope Scope) (Changes, error) {
 	repo, err := git.PlainOpen(srcDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse the srcDir git data: %w", err)
+		return Changes{}, fmt.Errorf("failed to parse the srcDir git data: %w", err)
 	}
 	headRef, err := repo.Head()
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve HEAD ref: %w", err)
+		return Changes{}, fmt.Errorf("failed to retrieve HEAD ref: %w", err)
 	}
 	commit, err := repo.CommitObject(headRef.Hash())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get the commit object for HEAD ref: %w", err)
+		return Changes{}, fmt.Errorf("failed to get the commit object for HEAD ref: %w", err)
+	}
+	changes := Changes{Head: commit.Hash.String(), Files: map[string][]LineRange{}}
+	if scope == ScopeAll {
+		files, err := commit.Files()
+		if err != nil {
+			return Changes{}, fmt.Errorf("failed to list files at HEAD: %w", err)
+		}
+		err = files.ForEach(func(f *object.File) error {
+			changes.Files[f.Name] = nil
+			return nil
+		})
+		if err != nil {
+			return Changes{}, fmt.Errorf("failed to list files at HEAD: %w", err)
+		}
+		return changes, nil
+	}
+	baseHash, err := resolveRevision(repo, base)
+	if err != nil {
+		return Changes{}, err
 	}
-	mainRef, err := repo.Reference(plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", relBranch)), true)
+	baseCommit, err := repo.CommitObject(*baseHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get the relative branch ref: %w", err)
+		return Changes{}, fmt.Errorf("failed to get the commit object for base revision: %w", err)
 	}
-	mainHead, err := repo.CommitObject(mainRef.Hash())
+	bases, err := commit.MergeBase(baseCommit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get the commit object for relative branch: %w", err)
+		return Changes{}, fmt.Errorf("failed to compute the merge base of HEAD and base revision: %w", err)
+	}
+	if len(bases) == 0 {
+		return Changes{}, fmt.Errorf("HEAD and %s have no common ancestor, the clone may be too shallow", base)
 	}
-	diff, err := commit.Patch(mainHead)
+	mergeBase := bases[0]
+	changes.Base = mergeBase.Hash.String()
+	patch, err := mergeBase.Patch(commit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get diff between HEAD and relative branch: %w", err)
+		return Changes{}, fmt.Errorf("failed to get diff between HEAD and merge base: %w", err)
+	}
+	for _, filePatch := range patch.FilePatches() {
+		_, to := filePatch.Files()
+		if to == nil {
+			// deleted on HEAD, nothing left to inspect
+			continue
+		}
+		if scope == ScopeFiles {
+			changes.Files[to.Path()] = nil
+			continue
+		}
+		ranges := changedLines(filePatch)
+		if len(ranges) == 0 {
+			continue
+		}
+		changes.Files[to.Path()] = ranges
+	}
+	return changes, nil
+}
+
+// resolveRevision resolves rev to a commit hash. Anything go-git can resolve
+// is accepted: local and remote branches, tags, full or abbreviated SHAs and
+// expressions such as HEAD~3. Names that don't resolve locally fall back to
+// origin/<rev>, since CI checkouts often only have the remote-tracking branch.
+func resolveRevision(repo *git.Repository, rev string) (*plumbing.Hash, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err == nil {
+		return hash, nil
+	}
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		if hash, remoteErr := repo.ResolveRevision(plumbing.Revision("origin/" + rev)); remoteErr == nil {
+			return hash, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to resolve base revision %q: %w", rev, err)
+}
+
+// changedLines returns the line ranges of the new side of filePatch that
+// were added or modified.
+func changedLines(filePatch diff.FilePatch) []LineRange {
+	var ranges []LineRange
+	line := uint32(1)
+	for _, chunk := range filePatch.Chunks() {
+		n := countLines(chunk.Content())
+		switch chunk.Type() {
+		case diff.Equal:
+			line += n
+		case diff.Add:
+			if n == 0 {
+				continue
+			}
+			ranges = append(ranges, LineRange{Start: line, End: line + n - 1})
+			line += n
+		}
 	}
-	var files []string
-	for _, stat := range diff.Stats() {
-		files = append(files, stat.Name)
+	return ranges
+}
+
+func countLines(content string) uint32 {
+	n := strings.Count(content, "\n")
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		n++
 	}
-	return files, nil
+	return uint32(n)
 }
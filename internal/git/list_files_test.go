@@ -0,0 +1,215 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// testRepo is a throwaway repository used to exercise the diffing logic.
+type testRepo struct {
+	t    *testing.T
+	dir  string
+	repo *git.Repository
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init repository: %s", err)
+	}
+	return &testRepo{t: t, dir: dir, repo: repo}
+}
+
+// commit writes files (a nil content removes the file) and commits them on
+// the current branch.
+func (r *testRepo) commit(files map[string]*string) plumbing.Hash {
+	r.t.Helper()
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		r.t.Fatalf("failed to get worktree: %s", err)
+	}
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		if content == nil {
+			if _, err := wt.Remove(name); err != nil {
+				r.t.Fatalf("failed to remove %s: %s", name, err)
+			}
+			continue
+		}
+		if err := os.WriteFile(path, []byte(*content), 0o644); err != nil {
+			r.t.Fatalf("failed to write %s: %s", name, err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			r.t.Fatalf("failed to add %s: %s", name, err)
+		}
+	}
+	hash, err := wt.Commit("commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "citk", Email: "citk@example.com", When: time.Now()},
+	})
+	if err != nil {
+		r.t.Fatalf("failed to commit: %s", err)
+	}
+	return hash
+}
+
+// checkout moves the worktree to hash. A non-empty branch is created at hash
+// and checked out instead of detaching HEAD.
+func (r *testRepo) checkout(hash plumbing.Hash, branch string) {
+	r.t.Helper()
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		r.t.Fatalf("failed to get worktree: %s", err)
+	}
+	opts := &git.CheckoutOptions{Hash: hash}
+	if branch != "" {
+		opts.Branch = plumbing.NewBranchReferenceName(branch)
+		opts.Create = true
+	}
+	if err := wt.Checkout(opts); err != nil {
+		r.t.Fatalf("failed to checkout %s: %s", hash, err)
+	}
+}
+
+func content(s string) *string {
+	return &s
+}
+
+func TestListChanges(t *testing.T) {
+	repo := newTestRepo(t)
+	base := repo.commit(map[string]*string{
+		"a.go":       content("package a\n\nfunc a() {}\n\nfunc b() {}\n"),
+		"removed.go": content("package a\n"),
+		"same.go":    content("package a\n"),
+	})
+	head := repo.commit(map[string]*string{
+		"a.go":       content("package a\n\nfunc x() {}\n\nfunc b() {}\n\nfunc e() {}"),
+		"new.go":     content("package a\n\nvar x = 1\n"),
+		"removed.go": nil,
+	})
+	// main moves on after HEAD branched off, which must not count as a
+	// change on HEAD.
+	repo.checkout(base, "main")
+	repo.commit(map[string]*string{
+		"same.go": content("package a\n\nvar y = 2\n"),
+	})
+	repo.checkout(head, "")
+
+	tests := []struct {
+		scope    Scope
+		expected map[string][]LineRange
+	}{
+		{
+			scope: ScopeLines,
+			expected: map[string][]LineRange{
+				"a.go":   {{Start: 3, End: 3}, {Start: 6, End: 7}},
+				"new.go": {{Start: 1, End: 3}},
+			},
+		},
+		{
+			scope: ScopeFiles,
+			expected: map[string][]LineRange{
+				"a.go":   nil,
+				"new.go": nil,
+			},
+		},
+		{
+			scope: ScopeAll,
+			expected: map[string][]LineRange{
+				"a.go":    nil,
+				"new.go":  nil,
+				"same.go": nil,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.scope), func(t *testing.T) {
+			changes, err := ListChanges(repo.dir, "main", test.scope)
+			if err != nil {
+				t.Fatalf("failed to list changes: %s", err)
+			}
+			if changes.Head != head.String() {
+				t.Errorf("expected head %s, returned %s", head, changes.Head)
+			}
+			if test.scope != ScopeAll && changes.Base != base.String() {
+				t.Errorf("expected merge base %s, returned %s", base, changes.Base)
+			}
+			if !reflect.DeepEqual(test.expected, changes.Files) {
+				t.Errorf("expected and returned values do not match: expected %+v, returned %+v", test.expected, changes.Files)
+			}
+		})
+	}
+}
+
+func TestChangesContains(t *testing.T) {
+	changes := Changes{Files: map[string][]LineRange{
+		"a.go": {{Start: 3, End: 5}},
+		"b.go": nil,
+	}}
+	tests := []struct {
+		file     string
+		line     uint32
+		expected bool
+	}{
+		{"a.go", 2, false},
+		{"a.go", 3, true},
+		{"a.go", 5, true},
+		{"a.go", 6, false},
+		{"b.go", 100, true},
+		{"c.go", 1, false},
+	}
+	for _, test := range tests {
+		if got := changes.Contains(test.file, test.line); got != test.expected {
+			t.Errorf("Contains(%q, %d) = %t, expected %t", test.file, test.line, got, test.expected)
+		}
+	}
+}
+
+func TestResolveRevision(t *testing.T) {
+	repo := newTestRepo(t)
+	first := repo.commit(map[string]*string{"a.go": content("package a\n")})
+	second := repo.commit(map[string]*string{"a.go": content("package b\n")})
+	repo.commit(map[string]*string{"a.go": content("package c\n")})
+	refs := []*plumbing.Reference{
+		plumbing.NewHashReference(plumbing.NewTagReferenceName("v1"), first),
+		plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "develop"), second),
+	}
+	for _, ref := range refs {
+		if err := repo.repo.Storer.SetReference(ref); err != nil {
+			t.Fatalf("failed to create %s: %s", ref.Name(), err)
+		}
+	}
+
+	tests := []struct {
+		rev      string
+		expected plumbing.Hash
+	}{
+		{"v1", first},
+		{"origin/develop", second},
+		{"develop", second},
+		{"HEAD~2", first},
+		{second.String(), second},
+		{first.String()[:7], first},
+	}
+	for _, test := range tests {
+		hash, err := resolveRevision(repo.repo, test.rev)
+		if err != nil {
+			t.Errorf("failed to resolve %q: %s", test.rev, err)
+			continue
+		}
+		if *hash != test.expected {
+			t.Errorf("resolveRevision(%q) = %s, expected %s", test.rev, hash, test.expected)
+		}
+	}
+	if _, err := resolveRevision(repo.repo, "missing"); err == nil {
+		t.Errorf("expected an error resolving a missing revision")
+	}
+}
@@ -0,0 +1,157 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+// maxAnnotationsPerRequest is the number of annotations the Checks API
+// accepts in a single request.
+const maxAnnotationsPerRequest = 50
+
+// maxSummaryLength is the longest summary the Checks API accepts.
+const maxSummaryLength = 65535
+
+// CheckRun describes a check run to create on a commit.
+type CheckRun struct {
+	Owner   string
+	Repo    string
+	Name    string
+	HeadSHA string
+	Title   string
+	// Summary is the Markdown shown on the check run page. It is truncated
+	// to the length the API accepts.
+	Summary string
+}
+
+type checkRunRequest struct {
+	Name       string          `json:"name,omitempty"`
+	HeadSHA    string          `json:"head_sha,omitempty"`
+	Status     string          `json:"status,omitempty"`
+	Conclusion string          `json:"conclusion,omitempty"`
+	Output     *checkRunOutput `json:"output,omitempty"`
+}
+
+type checkRunOutput struct {
+	Title       string               `json:"title"`
+	Summary     string               `json:"summary"`
+	Annotations []checkRunAnnotation `json:"annotations,omitempty"`
+}
+
+type checkRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       uint32 `json:"start_line"`
+	EndLine         uint32 `json:"end_line"`
+	StartColumn     uint32 `json:"start_column,omitempty"`
+	EndColumn       uint32 `json:"end_column,omitempty"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
+
+// CreateCheckRun creates a completed check run with the annotations. The
+// annotations are uploaded in batches, as the API limits how many a single
+// request can carry, and the conclusion follows from the most severe of
+// them. It returns the ID of the check run.
+func (c *Client) CreateCheckRun(run CheckRun, annotations []annotation.Annotation) (int64, error) {
+	summary := run.Summary
+	if len(summary) > maxSummaryLength {
+		summary = strings.ToValidUTF8(summary[:maxSummaryLength-3], "") + "..."
+	}
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	_, err := c.do(http.MethodPost, fmt.Sprintf("/repos/%s/%s/check-runs", run.Owner, run.Repo), checkRunRequest{
+		Name:    run.Name,
+		HeadSHA: run.HeadSHA,
+		Status:  "in_progress",
+		Output:  &checkRunOutput{Title: run.Title, Summary: summary},
+	}, &created)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create check run: %w", err)
+	}
+
+	batches := batchAnnotations(checkRunAnnotations(annotations))
+	path := fmt.Sprintf("/repos/%s/%s/check-runs/%d", run.Owner, run.Repo, created.ID)
+	for i, batch := range batches {
+		update := checkRunRequest{
+			Output: &checkRunOutput{Title: run.Title, Summary: summary, Annotations: batch},
+		}
+		if i == len(batches)-1 {
+			update.Status = "completed"
+			update.Conclusion = conclusion(annotations)
+		}
+		if _, err := c.do(http.MethodPatch, path, update, nil); err != nil {
+			return created.ID, fmt.Errorf("failed to update check run: %w", err)
+		}
+	}
+	return created.ID, nil
+}
+
+// batchAnnotations splits annotations into batches the API accepts. There
+// is always at least one, possibly empty, batch.
+func batchAnnotations(annotations []checkRunAnnotation) [][]checkRunAnnotation {
+	var batches [][]checkRunAnnotation
+	for len(annotations) > maxAnnotationsPerRequest {
+		batches = append(batches, annotations[:maxAnnotationsPerRequest])
+		annotations = annotations[maxAnnotationsPerRequest:]
+	}
+	return append(batches, annotations)
+}
+
+func checkRunAnnotations(annotations []annotation.Annotation) []checkRunAnnotation {
+	var converted []checkRunAnnotation
+	for _, a := range annotations {
+		// check run annotations have to point at a file
+		if a.FileName == "" || a.StartLine == 0 {
+			continue
+		}
+		ca := checkRunAnnotation{
+			Path:            filepath.ToSlash(a.FileName),
+			StartLine:       a.StartLine,
+			EndLine:         a.EndLine,
+			AnnotationLevel: annotationLevel(a.Type),
+			Title:           a.Title,
+			Message:         a.Message,
+		}
+		if ca.EndLine < ca.StartLine {
+			ca.EndLine = ca.StartLine
+		}
+		// columns are only accepted on single line annotations
+		if ca.StartLine == ca.EndLine {
+			ca.StartColumn = a.StartCol
+			ca.EndColumn = a.InclusiveEndCol()
+		}
+		converted = append(converted, ca)
+	}
+	return converted
+}
+
+// annotationLevel maps an AnnotationType to a check run annotation level.
+func annotationLevel(t annotation.AnnotationType) string {
+	switch t {
+	case annotation.Error:
+		return "failure"
+	case annotation.Warning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// conclusion derives the conclusion of a check run from its most severe
+// annotation.
+func conclusion(annotations []annotation.Annotation) string {
+	switch annotation.MaxSeverity(annotations) {
+	case annotation.Error:
+		return "failure"
+	case annotation.Warning:
+		return "neutral"
+	default:
+		return "success"
+	}
+}
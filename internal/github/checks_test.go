@@ -0,0 +1,108 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+func TestCreateCheckRun(t *testing.T) {
+	var annotations []annotation.Annotation
+	for i := 1; i <= 120; i++ {
+		annotations = append(annotations, annotation.Annotation{
+			FileName:  "main.go",
+			Message:   fmt.Sprintf("finding %d", i),
+			StartLine: uint32(i),
+			EndLine:   uint32(i),
+			StartCol:  2,
+			EndCol:    5,
+			Type:      annotation.Warning,
+		})
+	}
+	annotations[0].EndLine = 3
+
+	var (
+		created checkRunRequest
+		updates []checkRunRequest
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+			t.Errorf("failed to decode check run: %s", err)
+		}
+		fmt.Fprint(w, `{"id":42}`)
+	})
+	mux.HandleFunc("/repos/o/r/check-runs/42", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected a PATCH request, received %s", r.Method)
+		}
+		var update checkRunRequest
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			t.Errorf("failed to decode check run update: %s", err)
+		}
+		updates = append(updates, update)
+		fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	id, err := NewClient(server.URL, "token").CreateCheckRun(CheckRun{
+		Owner:   "o",
+		Repo:    "r",
+		Name:    "citk",
+		HeadSHA: "abc",
+		Title:   "120 finding(s)",
+		Summary: "## citk",
+	}, annotations)
+	if err != nil {
+		t.Fatalf("failed to create check run: %s", err)
+	}
+	if id != 42 {
+		t.Errorf("expected check run 42, returned %d", id)
+	}
+	if created.Name != "citk" || created.HeadSHA != "abc" || created.Status != "in_progress" || created.Output.Summary != "## citk" {
+		t.Errorf("unexpected check run: %+v", created)
+	}
+
+	var sizes []int
+	for _, update := range updates {
+		sizes = append(sizes, len(update.Output.Annotations))
+	}
+	if expected := []int{50, 50, 20}; !reflect.DeepEqual(expected, sizes) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, sizes)
+	}
+	last := updates[len(updates)-1]
+	if last.Status != "completed" || last.Conclusion != "neutral" || updates[0].Status != "" {
+		t.Errorf("expected only the last update to complete the check run, returned %+v", updates)
+	}
+	expected := []checkRunAnnotation{
+		{Path: "main.go", StartLine: 1, EndLine: 3, AnnotationLevel: "warning", Message: "finding 1"},
+		{Path: "main.go", StartLine: 2, EndLine: 2, StartColumn: 2, EndColumn: 4, AnnotationLevel: "warning", Message: "finding 2"},
+	}
+	if returned := updates[0].Output.Annotations[:2]; !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+}
+
+func TestConclusion(t *testing.T) {
+	tests := map[annotation.AnnotationType]string{
+		annotation.Error:   "failure",
+		annotation.Warning: "neutral",
+		annotation.Notice:  "success",
+		"":                 "success",
+	}
+	for severity, expected := range tests {
+		var annotations []annotation.Annotation
+		if severity != "" {
+			annotations = append(annotations, annotation.Annotation{Type: severity})
+		}
+		if returned := conclusion(annotations); expected != returned {
+			t.Errorf("expected %s for %q, returned %s", expected, severity, returned)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DefaultBaseURL is the URL of the GitHub REST API on github.com.
+const DefaultBaseURL = "https://api.github.com"
+
+// Client is a minimal client of the GitHub REST API.
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client authenticating with token against the API at
+// baseURL, or DefaultBaseURL when it's empty.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Token:      token,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// APIError is an error response of the API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GitHub API responded with %d: %s", e.StatusCode, e.Message)
+}
+
+// do sends a request with body encoded as JSON, when it isn't nil, and
+// decodes the response into out, when it isn't nil. path is either relative
+// to BaseURL or an absolute URL, like the ones in Link headers.
+func (c *Client) do(method, path string, body, out any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	url := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		url = c.BaseURL + path
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		b, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(b, &apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(b))
+		}
+		return resp, &APIError{StatusCode: resp.StatusCode, Message: apiErr.Message}
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("failed to decode GitHub response: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+var nextLinkRE = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// getAll follows the pagination of a list endpoint and returns the items of
+// every page.
+func getAll[T any](c *Client, path string) ([]T, error) {
+	var all []T
+	for path != "" {
+		var page []T
+		resp, err := c.do(http.MethodGet, path, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		path = ""
+		if m := nextLinkRE.FindStringSubmatch(resp.Header.Get("Link")); m != nil {
+			path = m[1]
+		}
+	}
+	return all, nil
+}
+
+// PullRequest identifies the pull request a workflow runs for.
+type PullRequest struct {
+	Owner  string
+	Repo   string
+	Number int
+	// HeadSHA is the last commit of the pull request. Workflows check out a
+	// merge commit instead, which comments can't be attached to.
+	HeadSHA string
+}
+
+// RepositoryFromEnv returns the owner and name of the repository in
+// GITHUB_REPOSITORY.
+func RepositoryFromEnv() (owner, repo string, err error) {
+	owner, repo, ok := strings.Cut(os.Getenv("GITHUB_REPOSITORY"), "/")
+	if !ok || owner == "" || repo == "" {
+		return "", "", errors.New("GITHUB_REPOSITORY has to be set to owner/repo")
+	}
+	return owner, repo, nil
+}
+
+// event is the part of the webhook payload of the event that triggered a
+// workflow run citk uses.
+type event struct {
+	PullRequest *struct {
+		Number int `json:"number"`
+		Head   struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	} `json:"pull_request"`
+}
+
+func readEvent() (event, error) {
+	path := os.Getenv("GITHUB_EVENT_PATH")
+	if path == "" {
+		return event{}, errors.New("GITHUB_EVENT_PATH has to be set")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return event{}, fmt.Errorf("failed to read event payload: %w", err)
+	}
+	var e event
+	if err := json.Unmarshal(b, &e); err != nil {
+		return event{}, fmt.Errorf("failed to decode event payload: %w", err)
+	}
+	return e, nil
+}
+
+// PullRequestFromEnv reads the pull request from the environment of a
+// GitHub Actions workflow run: GITHUB_REPOSITORY and the event payload at
+// GITHUB_EVENT_PATH.
+func PullRequestFromEnv() (PullRequest, error) {
+	owner, repo, err := RepositoryFromEnv()
+	if err != nil {
+		return PullRequest{}, err
+	}
+	e, err := readEvent()
+	if err != nil {
+		return PullRequest{}, err
+	}
+	if e.PullRequest == nil {
+		return PullRequest{}, errors.New("the workflow wasn't triggered by a pull request event")
+	}
+	return PullRequest{
+		Owner:   owner,
+		Repo:    repo,
+		Number:  e.PullRequest.Number,
+		HeadSHA: e.PullRequest.Head.SHA,
+	}, nil
+}
+
+// HeadSHAFromEnv returns the commit a workflow run is about: the head of
+// the pull request for pull request events and GITHUB_SHA otherwise.
+func HeadSHAFromEnv() (string, error) {
+	if e, err := readEvent(); err == nil && e.PullRequest != nil && e.PullRequest.Head.SHA != "" {
+		return e.PullRequest.Head.SHA, nil
+	}
+	sha := os.Getenv("GITHUB_SHA")
+	if sha == "" {
+		return "", errors.New("GITHUB_SHA has to be set")
+	}
+	return sha, nil
+}
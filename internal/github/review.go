@@ -0,0 +1,155 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+// reviewComment is a comment on a line of a pull request's diff.
+type reviewComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side"`
+	Body string `json:"body"`
+}
+
+type review struct {
+	CommitID string          `json:"commit_id,omitempty"`
+	Event    string          `json:"event"`
+	Body     string          `json:"body"`
+	Comments []reviewComment `json:"comments"`
+}
+
+type pullRequestFile struct {
+	Filename string `json:"filename"`
+	Patch    string `json:"patch"`
+}
+
+// markerRE finds the finding keys citk hides in the comments it posts.
+var markerRE = regexp.MustCompile(`<!-- citk:([0-9a-f]+) -->`)
+
+func marker(key string) string {
+	return fmt.Sprintf("<!-- citk:%s -->", key)
+}
+
+// PostReview creates a single review on pr with an inline comment for each
+// annotation on a line of the diff. Annotations outside the diff are listed
+// in the body of the review instead. Findings citk already reported on the
+// pull request, in comments or review bodies, are skipped, so it returns
+// the number of newly reported annotations. No review is created when there
+// is nothing new to report.
+func (c *Client) PostReview(pr PullRequest, annotations []annotation.Annotation) (int, error) {
+	base := fmt.Sprintf("/repos/%s/%s/pulls/%d", pr.Owner, pr.Repo, pr.Number)
+
+	files, err := getAll[pullRequestFile](c, base+"/files?per_page=100")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pull request files: %w", err)
+	}
+	diffLines := make(map[string]map[int]bool, len(files))
+	for _, f := range files {
+		diffLines[f.Filename] = commentableLines(f.Patch)
+	}
+
+	reported, err := c.reportedFingerprints(base)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		comments []reviewComment
+		outside  []string
+	)
+	keys := annotation.UniqueFingerprints(annotations)
+	for i, a := range annotations {
+		if reported[keys[i]] {
+			continue
+		}
+		path := filepath.ToSlash(a.FileName)
+		if diffLines[path][int(a.StartLine)] {
+			comments = append(comments, reviewComment{
+				Path: path,
+				Line: int(a.StartLine),
+				Side: "RIGHT",
+				Body: commentBody(a, keys[i]),
+			})
+			continue
+		}
+		outside = append(outside, fmt.Sprintf("- `%s:%d` %s", path, a.StartLine, commentBody(a, keys[i])))
+	}
+	if len(comments) == 0 && len(outside) == 0 {
+		return 0, nil
+	}
+
+	body := fmt.Sprintf("citk found %d new issue(s).", len(comments)+len(outside))
+	if len(outside) > 0 {
+		body += "\n\nOutside of the diff:\n\n" + strings.Join(outside, "\n")
+	}
+	_, err = c.do(http.MethodPost, base+"/reviews", review{
+		CommitID: pr.HeadSHA,
+		Event:    "COMMENT",
+		Body:     body,
+		Comments: comments,
+	}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create review: %w", err)
+	}
+	return len(comments) + len(outside), nil
+}
+
+// reportedFingerprints collects the keys, as returned by
+// annotation.UniqueFingerprints, of the findings citk reported on the pull
+// request before.
+func (c *Client) reportedFingerprints(base string) (map[string]bool, error) {
+	type withBody struct {
+		Body string `json:"body"`
+	}
+	comments, err := getAll[withBody](c, base+"/comments?per_page=100")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review comments: %w", err)
+	}
+	reviews, err := getAll[withBody](c, base+"/reviews?per_page=100")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+	reported := map[string]bool{}
+	for _, item := range append(comments, reviews...) {
+		for _, m := range markerRE.FindAllStringSubmatch(item.Body, -1) {
+			reported[m[1]] = true
+		}
+	}
+	return reported, nil
+}
+
+func commentBody(a annotation.Annotation, key string) string {
+	return fmt.Sprintf("**%s** `%s`: %s %s", a.Type, a.RuleID, a.Message, marker(key))
+}
+
+var hunkRE = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// commentableLines returns the lines of the new version of a file that can
+// be commented on, which are the added and context lines of its patch.
+func commentableLines(patch string) map[int]bool {
+	lines := map[int]bool{}
+	line := 0
+	for _, l := range strings.Split(patch, "\n") {
+		if m := hunkRE.FindStringSubmatch(l); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if line == 0 || l == "" {
+			continue
+		}
+		switch l[0] {
+		case '+', ' ':
+			lines[line] = true
+			line++
+		}
+	}
+	return lines
+}
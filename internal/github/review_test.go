@@ -0,0 +1,111 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+func TestCommentableLines(t *testing.T) {
+	patch := "@@ -1,3 +1,4 @@\n package main\n+\n-var a = 1\n+var b = 1\n \n@@ -20 +21,2 @@ func main() {\n+\tx := 1\n \ty := 2\n\\ No newline at end of file"
+	expected := map[int]bool{1: true, 2: true, 3: true, 4: true, 21: true, 22: true}
+	if returned := commentableLines(patch); !reflect.DeepEqual(expected, returned) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+}
+
+func TestPostReview(t *testing.T) {
+	posted := annotation.Annotation{FileName: "main.go", RuleID: "go-var-naming", Message: "old finding", StartLine: 2, Type: annotation.Error}
+	inDiff := annotation.Annotation{FileName: "main.go", RuleID: "go-func-naming", Message: "new finding", StartLine: 3, Type: annotation.Error}
+	outsideDiff := annotation.Annotation{FileName: "main.go", RuleID: "go-func-naming", Message: "far away", StartLine: 40, Type: annotation.Warning}
+	// the same finding on another line, like the same misnamed variable in
+	// two functions, is a finding of its own
+	repeated := inDiff
+	repeated.StartLine = 2
+	annotations := []annotation.Annotation{posted, inDiff, outsideDiff, repeated}
+	keys := annotation.UniqueFingerprints(annotations)
+	if keys[1] == keys[3] {
+		t.Fatalf("expected repeated findings to have unique keys, returned %q", keys)
+	}
+
+	var (
+		created review
+		posts   int
+		// bodies of the reviews and their comments, as listed by the API
+		bodies []string
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/o/r/pulls/7/files", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `[{"filename":"main.go","patch":"@@ -1,2 +1,3 @@\n package main\n+var x = 1\n+func f() {}"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/pulls/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			// the previous comment is on the second page
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/repos/o/r/pulls/7/comments?page=2>; rel="next"`, r.Host))
+			fmt.Fprint(w, `[{"body":"not from citk"}]`)
+			return
+		}
+		body, _ := json.Marshal(commentBody(posted, keys[0]))
+		fmt.Fprintf(w, `[{"body":%s}]`, body)
+	})
+	mux.HandleFunc("/repos/o/r/pulls/7/reviews", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			var reviews []map[string]string
+			for _, body := range bodies {
+				reviews = append(reviews, map[string]string{"body": body})
+			}
+			json.NewEncoder(w).Encode(reviews)
+			return
+		}
+		posts++
+		if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+			t.Errorf("failed to decode review: %s", err)
+		}
+		bodies = append(bodies, created.Body)
+		for _, c := range created.Comments {
+			bodies = append(bodies, c.Body)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	pr := PullRequest{Owner: "o", Repo: "r", Number: 7, HeadSHA: "abc"}
+	n, err := client.PostReview(pr, annotations)
+	if err != nil {
+		t.Fatalf("failed to post review: %s", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 new findings, returned %d", n)
+	}
+	expected := []reviewComment{
+		{Path: "main.go", Line: 3, Side: "RIGHT", Body: commentBody(inDiff, keys[1])},
+		{Path: "main.go", Line: 2, Side: "RIGHT", Body: commentBody(repeated, keys[3])},
+	}
+	if !reflect.DeepEqual(expected, created.Comments) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, created.Comments)
+	}
+	if created.CommitID != "abc" || created.Event != "COMMENT" || !strings.Contains(created.Body, "`main.go:40`") {
+		t.Errorf("unexpected review: %+v", created)
+	}
+
+	// posting the same findings again creates no review
+	n, err = client.PostReview(pr, annotations)
+	if err != nil {
+		t.Fatalf("failed to post review: %s", err)
+	}
+	if n != 0 || posts != 1 {
+		t.Errorf("expected no new review, returned %d new findings and %d reviews", n, posts)
+	}
+}
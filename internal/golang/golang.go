@@ -1,25 +1,23 @@
 package golang
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/golang"
 	"github.com/tjgurwara99/citk/internal/annotation"
-	"github.com/tjgurwara99/citk/internal/git"
+	"github.com/tjgurwara99/citk/internal/inspect"
 )
 
-type Ident struct {
-	Name    string
-	Line    uint32
-	EndLine uint32
-	Col     uint32
-	EndCol  uint32
+// Ident is an identifier found by one of the Go inspectors.
+type Ident = inspect.Ident
+
+// Language describes Go source files to the inspection engine.
+var Language = inspect.Language{
+	Name:       "go",
+	Extensions: []string{".go"},
+	Grammar:    golang.GetLanguage(),
 }
 
 var (
@@ -62,183 +60,150 @@ func checkCase(ident string) bool {
 	return false
 }
 
-func anomalousFuncSignatures(src []byte) ([]Ident, error) {
+func anomalousFuncSignatures(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
 	filterFuncDecls := `(
 		(function_declaration (identifier) @func)
 	)`
-	return anomalousDecls(src, filterFuncDecls, checkCase)
+	return anomalousDecls(src, grammar, filterFuncDecls, inspect.WithExceptions(checkCase, opts))
 }
 
-func anomalousConstDecls(src []byte) ([]Ident, error) {
+func anomalousConstDecls(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
 	filterConstDecls := `(
 		const_spec (identifier) @constant
 	)`
-	return anomalousDecls(src, filterConstDecls, checkCase)
+	return anomalousDecls(src, grammar, filterConstDecls, inspect.WithExceptions(checkCase, opts))
 }
 
-func anomalousVarDecls(src []byte) ([]Ident, error) {
+func anomalousVarDecls(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
 	filterVarDecls := `(
 		var_spec (identifier) @constant
 	)`
-	return anomalousDecls(src, filterVarDecls, checkCase)
+	return anomalousDecls(src, grammar, filterVarDecls, inspect.WithExceptions(checkCase, opts))
 }
 
-func anomalousMethodAndFieldDecls(src []byte) ([]Ident, error) {
+func anomalousMethodAndFieldDecls(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
 	// method_declaration (field_identifier) @methods
 	filterMethodDecls := `(
 		((field_identifier) @field)
 	)`
-	return anomalousDecls(src, filterMethodDecls, checkCase)
+	return anomalousDecls(src, grammar, filterMethodDecls, inspect.WithExceptions(checkCase, opts))
 }
 
-func anomalousPackageName(src []byte) ([]Ident, error) {
+func anomalousPackageName(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
 	filterPackageName := `(
 		((package_identifier) @field)
 	)`
-	return anomalousDecls(src, filterPackageName, func(ident string) bool {
-		if strings.Contains(ident, "_") && !strings.HasSuffix(ident, "_test") {
-			return true
-		}
-		if anyCapsRE.MatchString(ident) {
-			return true
-		}
-		return strings.ToLower(ident) != ident
-	})
+	return anomalousDecls(src, grammar, filterPackageName, inspect.WithExceptions(checkPackageName, opts))
 }
 
-func anomalousDecls(src []byte, query string, condition func(ident string) bool) ([]Ident, error) {
-	var decls []Ident
-
-	// Parse source code
-	lang := golang.GetLanguage()
-	n, err := sitter.ParseCtx(context.Background(), src, lang)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse source code: %w", err)
-	}
-	// Execute the query
-	q, err := sitter.NewQuery([]byte(query), lang)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create a query for lang: %w", err)
+func checkPackageName(ident string) bool {
+	if strings.Contains(ident, "_") && !strings.HasSuffix(ident, "_test") {
+		return true
 	}
-	qc := sitter.NewQueryCursor()
-	qc.Exec(q, n)
-	// Iterate over query results
-	for {
-		m, ok := qc.NextMatch()
-		if !ok {
-			break
-		}
-		// Apply predicates filtering
-		m = qc.FilterPredicates(m, src)
-		for _, c := range m.Captures {
-			if ident := c.Node.Content(src); condition(ident) {
-				decls = append(decls, Ident{
-					Name:    c.Node.Content(src),
-					Line:    c.Node.StartPoint().Row + 1,
-					EndLine: c.Node.EndPoint().Row + 1,
-					Col:     c.Node.StartPoint().Column,
-					EndCol:  c.Node.EndPoint().Column,
-				})
-			}
-		}
+	if anyCapsRE.MatchString(ident) {
+		return true
 	}
-	return decls, nil
+	return strings.ToLower(ident) != ident
 }
 
-func filterFiles(files []string, suffix string, srcDir string) []string {
-	var filteredFiles []string
-	for _, file := range files {
-		if strings.HasSuffix(file, suffix) {
-			filteredFiles = append(filteredFiles, filepath.Join(srcDir, file))
-		}
-	}
-	return filteredFiles
+func anomalousDecls(src []byte, grammar *sitter.Language, query string, condition func(ident string) bool) ([]Ident, error) {
+	return inspect.Query(src, grammar, query, "", condition)
 }
 
-type inspectFunc func([]byte) ([]Ident, error)
-
-type InspectFunc func([]byte, string, string) ([]annotation.Annotation, error)
-
-func WrapInspectFuncs(f inspectFunc, title, msgFmt string, t annotation.AnnotationType) InspectFunc {
-	return func(src []byte, baseDir, fName string) ([]annotation.Annotation, error) {
-		idents, err := f(src)
-		if err != nil {
-			return nil, err
-		}
-		var annotations []annotation.Annotation
-		for _, ident := range idents {
-			f, err := filepath.Rel(baseDir, fName)
-			if err != nil {
-				return nil, err
-			}
-			annotations = append(annotations, annotation.Annotation{
-				FileName:  f,
-				Title:     title,
-				Message:   fmt.Sprintf(msgFmt, ident.Name),
-				Type:      t,
-				StartLine: ident.Line,
-				EndLine:   ident.EndLine,
-				StartCol:  ident.Col,
-				EndCol:    ident.EndCol,
-			})
-		}
-		return annotations, nil
-	}
+// Rules lists the conventions Inspect checks Go source files against.
+var Rules = []inspect.Rule{
+	{
+		ID:          "go-const-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Const declaration not following style guide",
+		Description: "Constants use MixedCaps or mixedCaps like any other Go identifier. Underscores and SCREAMING_SNAKE_CASE are reserved for other languages.",
+		Examples: []inspect.Example{{
+			Bad:  "const MAX_RETRIES = 3",
+			Good: "const maxRetries = 3",
+		}},
+		Message:  "The declaration of the const %s is not following our style guide. Please read our contribution guidelines and style guide to help you resolve this issue.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousConstDecls,
+	},
+	{
+		ID:          "go-func-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Func declaration not following our style guide",
+		Description: "Function names use MixedCaps or mixedCaps and must not contain underscores.",
+		Examples: []inspect.Example{{
+			Bad:  "func parse_config() {}",
+			Good: "func parseConfig() {}",
+		}},
+		Message:  "The declaration of the function %s is not following our style guide. Please read our contribution guidelines and style guides to help you resolve this issue.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousFuncSignatures,
+	},
+	{
+		ID:          "go-field-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Struct fields/methods not following our style guide",
+		Description: "Struct fields and methods use MixedCaps or mixedCaps and must not contain underscores.",
+		Examples: []inspect.Example{{
+			Bad:  "type server struct {\n\tlisten_addr string\n}",
+			Good: "type server struct {\n\tlistenAddr string\n}",
+		}},
+		Message:  "The declaration of the method/field %s is not following our style guide. Please read our contribution guidelines and style guides to help you resolve this issue.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousMethodAndFieldDecls,
+	},
+	{
+		ID:          "go-initialism-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Initialism not written in a consistent case",
+		Description: "Initialisms and acronyms in names keep a consistent case: userID, ServeHTTP and parseURL rather than userId, ServeHttp and parseUrl. The initialisms option replaces the list of initialisms checked for.",
+		Examples: []inspect.Example{{
+			Bad:  "func parseUrl(rawUrl string) {}",
+			Good: "func parseURL(rawURL string) {}",
+		}},
+		Message:  "The declaration of %s is not following our style guide, initialisms have to be written in a consistent case. Please read our contribution guidelines and style guide to help you resolve this issue.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption, InitialismsOption},
+		Inspect:  anomalousInitialisms,
+	},
+	{
+		ID:          "go-package-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Package name not following our style guide",
+		Description: "Package names are short, lower case, single words without underscores or mixedCaps. Only external test packages may use the _test suffix.",
+		Examples: []inspect.Example{{
+			Bad:  "package httpUtil",
+			Good: "package httputil",
+		}},
+		Message:  "The package declaration %s is not following our style guide. Please read our contribution guidelines and style guide to help you resolve this issue.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousPackageName,
+	},
+	{
+		ID:          "go-var-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Variable name not following our style guide",
+		Description: "Variables use MixedCaps or mixedCaps and must not contain underscores.",
+		Examples: []inspect.Example{{
+			Bad:  "var retry_count int",
+			Good: "var retryCount int",
+		}},
+		Message:  "The variable declaration %s is not following our style guide. Please read our contribution guidelines and style guide to help you resolve this issue.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousVarDecls,
+	},
 }
 
-func Inspect(srcDir string, relBranch string) ([]annotation.Annotation, error) {
-	files, err := git.ListChangedFiles(srcDir, relBranch)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve changed files from git: %w", err)
-	}
-	goFiles := filterFiles(files, ".go", srcDir)
-
-	var annotations []annotation.Annotation
-	inspectFuncs := []InspectFunc{
-		WrapInspectFuncs(
-			anomalousConstDecls,
-			"Const declaration not following style guide",
-			"The declaration of the const %s is not following our style guide. Please read our contribution guidelines and style guide to help you resolve this issue.",
-			annotation.Error,
-		),
-		WrapInspectFuncs(
-			anomalousFuncSignatures,
-			"Func declaration not following our style guide",
-			"The declaration of the function %s is not following our style guide. Please read our contribution guidelines and style guides to help you resolve this issue.",
-			annotation.Error,
-		),
-		WrapInspectFuncs(
-			anomalousMethodAndFieldDecls,
-			"Struct fields/methods not following our style guide",
-			"The declaration of the method/field %s is not following our style guide. Please read our contribution guidelines and style guides to help you resolve this issue.",
-			annotation.Error,
-		),
-		WrapInspectFuncs(
-			anomalousPackageName,
-			"Package name not following our style guide",
-			"The package declaration %s is not following our style guide. Please read our contribution guidelines and style guide to help you resolve this issue.",
-			annotation.Error,
-		),
-		WrapInspectFuncs(
-			anomalousVarDecls,
-			"Variable name not following our style guide",
-			"The variable declaration %s is not following our style guide. Please read our contribution guidelines and style guide to help you resolve this issue.",
-			annotation.Error,
-		),
-	}
-
-	for _, file := range goFiles {
-		for _, inspector := range inspectFuncs {
-			srcFile, err := os.ReadFile(file)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read file: %w", err)
-			}
-			idents, err := inspector(srcFile, srcDir, file)
-			if err != nil {
-				return nil, fmt.Errorf("failed to run inspector on src file: %w", err)
-			}
-			annotations = append(annotations, idents...)
-		}
-	}
-	return annotations, nil
+func init() {
+	inspect.MustRegister(Rules...)
 }
@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/tjgurwara99/citk/internal/git"
+	"github.com/tjgurwara99/citk/internal/inspect"
 )
 
 func TestAnomalousFuncSignatures(t *testing.T) {
@@ -13,7 +16,7 @@ func TestAnomalousFuncSignatures(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to open testdata/funcs.go: %s", err.Error())
 	}
-	funcs, err := anomalousFuncSignatures(file)
+	funcs, err := anomalousFuncSignatures(file, Language.Grammar, nil)
 	if err != nil {
 		t.Errorf("returned an error: %s", err)
 	}
@@ -23,14 +26,14 @@ func TestAnomalousFuncSignatures(t *testing.T) {
 			Name:    "snake_case_function",
 			Line:    15,
 			EndLine: 15,
-			Col:     5,
-			EndCol:  24,
+			Col:     6,
+			EndCol:  25,
 		}, {
 			Name:    "SCREAMING_SNAKE_CASE_FUNCTION",
 			Line:    17,
 			EndLine: 17,
-			Col:     5,
-			EndCol:  34,
+			Col:     6,
+			EndCol:  35,
 		},
 	}
 
@@ -44,7 +47,7 @@ func TestAnomalousConstDecls(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to open testdata/consts.go: %s", err.Error())
 	}
-	funcs, err := anomalousConstDecls(file)
+	funcs, err := anomalousConstDecls(file, Language.Grammar, nil)
 	if err != nil {
 		t.Errorf("returned an error: %s", err)
 	}
@@ -54,14 +57,14 @@ func TestAnomalousConstDecls(t *testing.T) {
 			Name:    "snake_case_const",
 			Line:    6,
 			EndLine: 6,
-			Col:     1,
-			EndCol:  17,
+			Col:     2,
+			EndCol:  18,
 		}, {
 			Name:    "SCREAMING_SNAKE_CASE_CONST",
 			Line:    7,
 			EndLine: 7,
-			Col:     1,
-			EndCol:  27,
+			Col:     2,
+			EndCol:  28,
 		},
 	}
 
@@ -75,7 +78,7 @@ func TestAnomalousVarDecls(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to open testdata/vars.go: %s", err.Error())
 	}
-	funcs, err := anomalousVarDecls(file)
+	funcs, err := anomalousVarDecls(file, Language.Grammar, nil)
 	if err != nil {
 		t.Errorf("returned an error: %s", err)
 	}
@@ -85,14 +88,14 @@ func TestAnomalousVarDecls(t *testing.T) {
 			Name:    "snake_case_var",
 			Line:    6,
 			EndLine: 6,
-			Col:     1,
-			EndCol:  15,
+			Col:     2,
+			EndCol:  16,
 		}, {
 			Name:    "SCREAMING_SNAKE_CASE_VAR",
 			Line:    7,
 			EndLine: 7,
-			Col:     1,
-			EndCol:  25,
+			Col:     2,
+			EndCol:  26,
 		},
 	}
 
@@ -106,7 +109,7 @@ func TestAnomalousPackageName(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to open testdata/weird_package_name.go: %s", err.Error())
 	}
-	pkgNames, err := anomalousPackageName(file)
+	pkgNames, err := anomalousPackageName(file, Language.Grammar, nil)
 	if err != nil {
 		t.Errorf("unexpected error occured: %s", err)
 	}
@@ -115,8 +118,8 @@ func TestAnomalousPackageName(t *testing.T) {
 			Name:    "SomeThing",
 			Line:    1,
 			EndLine: 1,
-			Col:     8,
-			EndCol:  17,
+			Col:     9,
+			EndCol:  18,
 		},
 	}
 	if !reflect.DeepEqual(expected, pkgNames) {
@@ -129,7 +132,7 @@ func TestAnomalousMethodAndFieldDecls(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to open testdata/methods.go: %s", err.Error())
 	}
-	funcs, err := anomalousMethodAndFieldDecls(file)
+	funcs, err := anomalousMethodAndFieldDecls(file, Language.Grammar, nil)
 	if err != nil {
 		t.Errorf("returned an error: %s", err)
 	}
@@ -139,29 +142,29 @@ func TestAnomalousMethodAndFieldDecls(t *testing.T) {
 			Name:    "snake_case_field",
 			Line:    6,
 			EndLine: 6,
-			Col:     1,
-			EndCol:  17,
+			Col:     2,
+			EndCol:  18,
 		},
 		{
 			Name:    "SCREAMING_SNAKE_CASE_FIELD",
 			Line:    7,
 			EndLine: 7,
-			Col:     1,
-			EndCol:  27,
+			Col:     2,
+			EndCol:  28,
 		},
 		{
 			Name:    "snake_case_method",
 			Line:    13,
 			EndLine: 13,
-			Col:     17,
-			EndCol:  34,
+			Col:     18,
+			EndCol:  35,
 		},
 		{
 			Name:    "SCREAMING_SNAKE_CASE_METHOD",
 			Line:    14,
 			EndLine: 14,
-			Col:     17,
-			EndCol:  44,
+			Col:     18,
+			EndCol:  45,
 		},
 	}
 
@@ -179,9 +182,121 @@ func TestInspect(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to get working directory: %s", err)
 	}
-	annotations, err := Inspect(filepath.Join(wd, "../git/testdata"), "main")
+	changes, err := git.ListChanges(filepath.Join(wd, "../git/testdata"), "main", git.ScopeLines)
+	if err != nil {
+		t.Fatalf("failed to list changes: %s", err)
+	}
+	result, err := inspect.Run(filepath.Join(wd, "../git/testdata"), changes, Language, Rules)
 	if err != nil {
 		t.Errorf("failed to run Inspect: %s", err)
 	}
-	fmt.Printf("%+v", annotations)
+	fmt.Printf("%+v", result.Annotations)
+}
+
+func TestInspectFileSuppressions(t *testing.T) {
+	file, err := os.ReadFile("./testdata/suppressions.go")
+	if err != nil {
+		t.Fatalf("failed to open testdata/suppressions.go: %s", err.Error())
+	}
+	result, err := inspect.File(file, "suppressions.go", Language, Rules)
+	if err != nil {
+		t.Fatalf("returned an error: %s", err)
+	}
+
+	type finding struct {
+		Title string
+		Line  uint32
+	}
+	var findings []finding
+	for _, a := range result.Annotations {
+		findings = append(findings, finding{Title: a.Title, Line: a.StartLine})
+	}
+	expected := []finding{
+		{Title: "Func declaration not following our style guide", Line: 17},
+		{Title: inspect.MissingReason.Title, Line: 12},
+		{Title: inspect.UnusedSuppression.Title, Line: 15},
+	}
+	if !reflect.DeepEqual(expected, findings) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, findings)
+	}
+
+	type suppressed struct {
+		RuleID string
+		Line   uint32
+		Reason string
+	}
+	var suppressedFindings []suppressed
+	for _, s := range result.Suppressed {
+		suppressedFindings = append(suppressedFindings, suppressed{RuleID: s.RuleID, Line: s.StartLine, Reason: s.Reason})
+	}
+	expectedSuppressed := []suppressed{
+		{RuleID: "go-const-naming", Line: 5, Reason: "mirrors the C header"},
+		{RuleID: "go-func-naming", Line: 7, Reason: "called from cgo"},
+		{RuleID: "go-var-naming", Line: 10, Reason: "kept for backwards compatibility"},
+		{RuleID: "go-var-naming", Line: 13, Reason: ""},
+	}
+	if !reflect.DeepEqual(expectedSuppressed, suppressedFindings) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expectedSuppressed, suppressedFindings)
+	}
+}
+
+func TestAnomalousInitialisms(t *testing.T) {
+	file, err := os.ReadFile("./testdata/initialisms.go")
+	if err != nil {
+		t.Fatalf("failed to open testdata/initialisms.go: %s", err.Error())
+	}
+	idents, err := anomalousInitialisms(file, Language.Grammar, inspect.Options{"initialisms": commonInitialisms})
+	if err != nil {
+		t.Fatalf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "HttpServer", Line: 3, EndLine: 3, Col: 6, EndCol: 16, Suggestion: "HTTPServer"},
+		{Name: "userId", Line: 4, EndLine: 4, Col: 2, EndCol: 8, Suggestion: "userID"},
+		{Name: "Url", Line: 4, EndLine: 4, Col: 10, EndCol: 13, Suggestion: "URL"},
+		{Name: "Api", Line: 7, EndLine: 7, Col: 6, EndCol: 9, Suggestion: "API"},
+		{Name: "GetId", Line: 8, EndLine: 8, Col: 2, EndCol: 7, Suggestion: "GetID"},
+		{Name: "parseUrl", Line: 11, EndLine: 11, Col: 6, EndCol: 14, Suggestion: "parseURL"},
+		{Name: "Uid", Line: 12, EndLine: 12, Col: 11, EndCol: 14, Suggestion: "UID"},
+		{Name: "Ip", Line: 15, EndLine: 15, Col: 6, EndCol: 8, Suggestion: "IP"},
+		{Name: "TtlSec", Line: 16, EndLine: 16, Col: 8, EndCol: 14, Suggestion: "TTLSec"},
+		{Name: "ServeHttp", Line: 20, EndLine: 20, Col: 22, EndCol: 31, Suggestion: "ServeHTTP"},
+		{Name: "Ids", Line: 22, EndLine: 22, Col: 58, EndCol: 61, Suggestion: "IDs"},
+		// Http_Server and parseUrl_test are left to go-var-naming
+	}
+	if !reflect.DeepEqual(expected, idents) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, idents)
+	}
+}
+
+func TestFixInitialisms(t *testing.T) {
+	defaults := map[string]bool{}
+	for _, initialism := range commonInitialisms {
+		defaults[initialism] = true
+	}
+	testCases := []struct {
+		ident       string
+		initialisms map[string]bool
+		expected    string
+	}{
+		{ident: "userId", initialisms: defaults, expected: "userID"},
+		{ident: "HttpServer", initialisms: defaults, expected: "HTTPServer"},
+		{ident: "XmlHttpRequest", initialisms: defaults, expected: "XMLHTTPRequest"},
+		{ident: "newUtf8Reader", initialisms: defaults, expected: "newUTF8Reader"},
+		{ident: "userIds", initialisms: defaults, expected: "userIDs"},
+		{ident: "id", initialisms: defaults, expected: "id"},
+		{ident: "HTTPServer", initialisms: defaults, expected: "HTTPServer"},
+		{ident: "JSONId", initialisms: defaults, expected: "JSONID"},
+		{ident: "parseJSONIds", initialisms: defaults, expected: "parseJSONIDs"},
+		{ident: "UIDs", initialisms: defaults, expected: "UIDs"},
+		{ident: "Identity", initialisms: defaults, expected: "Identity"},
+		{ident: "user_id", initialisms: defaults, expected: "user_id"},
+		{ident: "userDb", initialisms: map[string]bool{"DB": true}, expected: "userDB"},
+		{ident: "userId", initialisms: map[string]bool{"DB": true}, expected: "userId"},
+	}
+	for _, tc := range testCases {
+		if returned := fixInitialisms(tc.ident, tc.initialisms); returned != tc.expected {
+			t.Errorf("expected and returned values do not match: expected %+v, returned %+v", tc.expected, returned)
+		}
+	}
 }
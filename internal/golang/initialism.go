@@ -0,0 +1,124 @@
+package golang
+
+import (
+	"strings"
+	"unicode"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// commonInitialisms are the initialisms that keep a consistent case in Go
+// names, like in ServeHTTP or userID. The list is the one golint uses.
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP",
+	"HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA",
+	"SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID",
+	"URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// InitialismsOption sets the initialisms go-initialism-naming checks for.
+var InitialismsOption = inspect.Option{
+	Name:        "initialisms",
+	Description: "Initialisms that have to be written in a consistent case, replacing the default list.",
+	Default:     commonInitialisms,
+}
+
+func anomalousInitialisms(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	// only declarations are checked, as uses of names declared elsewhere
+	// can't be renamed where they're used. The names of const and var specs
+	// are matched as children, as name: only matches the first of several.
+	filterDecls := `[
+		(function_declaration name: (identifier) @name)
+		(method_declaration name: (field_identifier) @name)
+		(method_spec name: (field_identifier) @name)
+		(type_spec name: (type_identifier) @name)
+		(field_declaration name: (field_identifier) @name)
+		(const_spec (identifier) @name)
+		(var_spec (identifier) @name)
+		(parameter_declaration name: (identifier) @name)
+		(short_var_declaration left: (expression_list (identifier) @name))
+		(range_clause left: (expression_list (identifier) @name))
+	]`
+	initialisms := map[string]bool{}
+	for _, initialism := range opts.Strings(InitialismsOption.Name) {
+		initialisms[strings.ToUpper(initialism)] = true
+	}
+	check := func(ident string) bool {
+		if _, ok := knownNameExceptions[ident]; ok {
+			return false
+		}
+		// names with underscores are reported by the other naming rules,
+		// and HTTP_Server would be no better a name than Http_Server
+		if strings.Contains(ident, "_") {
+			return false
+		}
+		return fixInitialisms(ident, initialisms) != ident
+	}
+	idents, err := anomalousDecls(src, grammar, filterDecls, inspect.WithExceptions(check, opts))
+	if err != nil {
+		return nil, err
+	}
+	for i := range idents {
+		idents[i].Suggestion = fixInitialisms(idents[i].Name, initialisms)
+	}
+	return idents, nil
+}
+
+// fixInitialisms returns ident with the initialisms among its words, like
+// the Id in userId, written in upper case. Plurals like Ids become IDs.
+//
+// Words start at an upper case letter following anything but an upper case
+// letter, at the last letter of a run of upper case letters followed by a
+// lower case one, and at underscores. HTTPServer is split into HTTP and
+// Server and left alone, while JSONId becomes JSONID.
+func fixInitialisms(ident string, initialisms map[string]bool) string {
+	runes := []rune(ident)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && !wordBoundary(runes, i) {
+			continue
+		}
+		fixWord(runes[start:i], initialisms)
+		start = i
+	}
+	return string(runes)
+}
+
+// wordBoundary reports whether a word starts at runes[i].
+func wordBoundary(runes []rune, i int) bool {
+	prev, r := runes[i-1], runes[i]
+	switch {
+	case r == '_' || prev == '_':
+		return true
+	case !unicode.IsUpper(r):
+		return false
+	case !unicode.IsUpper(prev):
+		return true
+	default:
+		return i+1 < len(runes) && unicode.IsLower(runes[i+1])
+	}
+}
+
+// fixWord rewrites word in place when it is a known initialism, or the
+// plural of one, written in mixed case.
+func fixWord(word []rune, initialisms map[string]bool) {
+	upper := strings.ToUpper(string(word))
+	fixed := upper
+	switch {
+	case initialisms[upper]:
+	case len(word) > 1 && word[len(word)-1] == 's' && initialisms[upper[:len(upper)-1]]:
+		fixed = upper[:len(upper)-1] + "s"
+	default:
+		return
+	}
+	// all lower case words only start unexported names, like id, or follow
+	// underscores, like the test in a _test suffix
+	if strings.ToLower(string(word)) == string(word) {
+		return
+	}
+	// changing the case of some letters changes their number
+	if runes := []rune(fixed); len(runes) == len(word) {
+		copy(word, runes)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+type HttpServer struct {
+	userId, Url string
+}
+
+type Api interface {
+	GetId() int
+}
+
+func parseUrl(jsonData string) (xmlOut string) {
+	for idx, Uid := range m {
+	}
+	htmlBody := 1
+	var Ip = 1
+	const TtlSec = 1
+	return
+}
+
+func (s *HttpServer) ServeHttp() {}
+
+func LastInsertId() (id int64, userID string, IDs []int, Ids []int, HTTPServer string) {
+	return
+}
+
+var Http_Server, parseUrl_test int
@@ -0,0 +1,17 @@
+package main
+
+//citk:ignore-file go-const-naming -- mirrors the C header
+
+const snake_case_const = 1
+
+func snake_case_function() {} //citk:ignore go-func-naming -- called from cgo
+
+//citk:ignore go-var-naming -- kept for backwards compatibility
+var snake_case_var = 1
+
+//citk:ignore go-var-naming
+var another_var = 1
+
+//citk:ignore go-func-naming -- nothing to suppress here
+
+func unsuppressed_function() {}
@@ -0,0 +1,157 @@
+package inspect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+// Option declares a setting a rule accepts in the config file. The type of
+// Default is the type values in the config file are decoded into.
+type Option struct {
+	Name        string
+	Description string
+	Default     any
+}
+
+// Options holds the option values a rule is run with, keyed by option name.
+type Options map[string]any
+
+// Strings returns the value of a []string option.
+func (o Options) Strings(name string) []string {
+	s, _ := o[name].([]string)
+	return s
+}
+
+// ExceptionsOption lets users exempt identifiers from a naming rule. Rules
+// declaring it wrap their check with WithExceptions.
+var ExceptionsOption = Option{
+	Name:        "exceptions",
+	Description: "Identifiers exempt from the rule.",
+	Default:     []string{},
+}
+
+// WithExceptions wraps check so the identifiers listed in the exceptions
+// option always pass.
+func WithExceptions(check func(ident string) bool, opts Options) func(ident string) bool {
+	exceptions := opts.Strings(ExceptionsOption.Name)
+	return func(ident string) bool {
+		for _, exception := range exceptions {
+			if ident == exception {
+				return false
+			}
+		}
+		return check(ident)
+	}
+}
+
+// RuleConfig is the configuration of a single rule in the rules section of
+// the config file.
+//
+//	rules:
+//	  go-var-naming:
+//	    enabled: true
+//	    severity: warning
+//	    options:
+//	      exceptions: [kWh]
+type RuleConfig struct {
+	Enabled  *bool          `mapstructure:"enabled"`
+	Severity string         `mapstructure:"severity"`
+	Options  map[string]any `mapstructure:"options"`
+}
+
+// Configure applies configs, keyed by rule ID, to the registered rules.
+// Unknown rules, invalid severities and unknown or mistyped options are all
+// reported as errors.
+func Configure(configs map[string]RuleConfig) error {
+	ids := make([]string, 0, len(configs))
+	for id := range configs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs []error
+	for _, id := range ids {
+		if err := configure(id, configs[id]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func configure(id string, config RuleConfig) error {
+	i := indexOf(id)
+	if i < 0 {
+		return fmt.Errorf("unknown rule %q", id)
+	}
+	rule := registry[i]
+	var errs []error
+	if config.Enabled != nil {
+		rule.Disabled = !*config.Enabled
+	}
+	if config.Severity != "" {
+		severity, err := annotation.ParseAnnotationType(config.Severity)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", id, err))
+		} else {
+			rule.Severity = severity
+		}
+	}
+	names := make([]string, 0, len(config.Options))
+	for name := range config.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// the registered rule keeps its options when the config is invalid
+	opts := make(Options, len(rule.Config)+len(names))
+	for name, value := range rule.Config {
+		opts[name] = value
+	}
+	for _, name := range names {
+		option, ok := rule.option(name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("rule %s has no option %q", id, name))
+			continue
+		}
+		decoded := reflect.New(reflect.TypeOf(option.Default))
+		if err := mapstructure.Decode(config.Options[name], decoded.Interface()); err != nil {
+			errs = append(errs, fmt.Errorf("rule %s option %s: %w", id, name, err))
+			continue
+		}
+		opts[name] = decoded.Elem().Interface()
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if len(opts) > 0 {
+		rule.Config = opts
+	}
+	registry[i] = rule
+	return nil
+}
+
+func (r Rule) option(name string) (Option, bool) {
+	for _, option := range r.Options {
+		if option.Name == name {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
+
+// options returns the values the rule runs with, falling back to the
+// defaults of the options that aren't configured.
+func (r Rule) options() Options {
+	opts := make(Options, len(r.Options))
+	for _, option := range r.Options {
+		opts[option.Name] = option.Default
+		if value, ok := r.Config[option.Name]; ok {
+			opts[option.Name] = value
+		}
+	}
+	return opts
+}
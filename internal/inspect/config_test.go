@@ -0,0 +1,95 @@
+package inspect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+func TestConfigure(t *testing.T) {
+	defer func(saved []Rule) { registry = saved }(registry)
+	registry = nil
+	MustRegister(
+		Rule{
+			ID:       "go-func-naming",
+			Language: "go",
+			Severity: annotation.Error,
+			Options:  []Option{{Name: "exceptions", Default: []string{}}},
+		},
+		Rule{ID: "go-var-naming", Language: "go", Severity: annotation.Error},
+	)
+
+	disabled := false
+	err := Configure(map[string]RuleConfig{
+		"go-func-naming": {
+			Severity: "warning",
+			Options:  map[string]any{"exceptions": []any{"kWh", "LastInsertId"}},
+		},
+		"go-var-naming": {Enabled: &disabled},
+	})
+	if err != nil {
+		t.Fatalf("failed to configure rules: %s", err)
+	}
+	rules := LanguageRules("go")
+	if len(rules) != 1 || rules[0].ID != "go-func-naming" {
+		t.Fatalf("expected only go-func-naming to be enabled, returned %+v", rules)
+	}
+	if rules[0].Severity != annotation.Warning {
+		t.Errorf("expected severity warning, returned %s", rules[0].Severity)
+	}
+	expected := Options{"exceptions": []string{"kWh", "LastInsertId"}}
+	if opts := rules[0].options(); !reflect.DeepEqual(expected, opts) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, opts)
+	}
+}
+
+func TestConfigureErrors(t *testing.T) {
+	defer func(saved []Rule) { registry = saved }(registry)
+	registry = nil
+	MustRegister(Rule{
+		ID:      "go-func-naming",
+		Options: []Option{{Name: "exceptions", Default: []string{}}},
+	})
+
+	tests := map[string]RuleConfig{
+		"go-unknown":     {},
+		"go-func-naming": {Severity: "fatal"},
+	}
+	for id, config := range tests {
+		if err := Configure(map[string]RuleConfig{id: config}); err == nil {
+			t.Errorf("expected an error configuring %s with %+v", id, config)
+		}
+	}
+	for name, value := range map[string]any{"other": true, "exceptions": 3} {
+		config := RuleConfig{Options: map[string]any{name: value}}
+		if err := Configure(map[string]RuleConfig{"go-func-naming": config}); err == nil {
+			t.Errorf("expected an error configuring option %s with %v", name, value)
+		}
+	}
+}
+
+func TestConfigureKeepsOptionsOnError(t *testing.T) {
+	defer func(saved []Rule) { registry = saved }(registry)
+	registry = nil
+	MustRegister(Rule{
+		ID: "go-func-naming",
+		Options: []Option{
+			{Name: "exceptions", Default: []string{}},
+			{Name: "initialisms", Default: []string{}},
+		},
+	})
+	err := Configure(map[string]RuleConfig{"go-func-naming": {Options: map[string]any{"exceptions": []any{"kWh"}}}})
+	if err != nil {
+		t.Fatalf("failed to configure rules: %s", err)
+	}
+	// a valid option next to an invalid one must not be applied
+	err = Configure(map[string]RuleConfig{"go-func-naming": {Options: map[string]any{"exceptions": []any{"other"}, "initialisms": 3}}})
+	if err == nil {
+		t.Fatalf("expected an error configuring initialisms with 3")
+	}
+	expected := Options{"exceptions": []string{"kWh"}, "initialisms": []string{}}
+	if opts := registry[0].options(); !reflect.DeepEqual(expected, opts) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, opts)
+	}
+}
@@ -0,0 +1,142 @@
+package inspect
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+// CustomRule is a rule declared in the config file as a tree-sitter query
+// rather than written in Go.
+//
+//	custom-rules:
+//	  - id: go-no-get-prefix
+//	    language: go
+//	    query: (function_declaration name: (identifier) @name)
+//	    capture: name
+//	    must-not-match: ^Get[A-Z]
+//	    title: Getter with a Get prefix
+//	    message: The function %s should not start with Get.
+//	    severity: warning
+//
+// Every node captured as capture is reported unless it is filtered out by
+// must-match or must-not-match. Predicates such as #match? in the query
+// itself are honoured too.
+type CustomRule struct {
+	ID           string `mapstructure:"id"`
+	Language     string `mapstructure:"language"`
+	Query        string `mapstructure:"query"`
+	Capture      string `mapstructure:"capture"`
+	MustMatch    string `mapstructure:"must-match"`
+	MustNotMatch string `mapstructure:"must-not-match"`
+	Category     string `mapstructure:"category"`
+	Title        string `mapstructure:"title"`
+	Description  string `mapstructure:"description"`
+	Message      string `mapstructure:"message"`
+	Severity     string `mapstructure:"severity"`
+}
+
+// Compile validates the custom rule and turns it into a Rule that runs its
+// query against files written in lang.
+func (c CustomRule) Compile(lang Language) (Rule, error) {
+	if c.ID == "" {
+		return Rule{}, fmt.Errorf("custom rule is missing an id")
+	}
+	// the config file lowercases the rule IDs of the rules section, so a
+	// rule with upper case letters in its ID couldn't be configured
+	if c.ID != strings.ToLower(c.ID) {
+		return Rule{}, fmt.Errorf("custom rule %s has upper case letters in its id", c.ID)
+	}
+	if c.Query == "" {
+		return Rule{}, fmt.Errorf("custom rule %s is missing a query", c.ID)
+	}
+	// the query runs against every dialect of the language
+	for _, grammar := range lang.Grammars() {
+		q, err := sitter.NewQuery([]byte(c.Query), grammar)
+		if err != nil {
+			return Rule{}, fmt.Errorf("custom rule %s has an invalid %s query: %w", c.ID, lang.Name, err)
+		}
+		if c.Capture != "" && !hasCapture(q, c.Capture) {
+			return Rule{}, fmt.Errorf("custom rule %s captures nothing as @%s", c.ID, c.Capture)
+		}
+	}
+	condition, err := c.condition()
+	if err != nil {
+		return Rule{}, err
+	}
+	if c.Message == "" {
+		return Rule{}, fmt.Errorf("custom rule %s is missing a message", c.ID)
+	}
+	message := c.Message
+	if !strings.Contains(message, "%s") {
+		// the offending identifier is always passed to Sprintf
+		message += "%.0s"
+	}
+	if strings.Contains(fmt.Sprintf(message, ""), "%!") {
+		return Rule{}, fmt.Errorf("custom rule %s message may only use %%s for the identifier and %%%% for a literal %%", c.ID)
+	}
+	severity := annotation.Error
+	if c.Severity != "" {
+		severity, err = annotation.ParseAnnotationType(c.Severity)
+		if err != nil {
+			return Rule{}, fmt.Errorf("custom rule %s: %w", c.ID, err)
+		}
+	}
+	title := c.Title
+	if title == "" {
+		title = c.ID
+	}
+	category := c.Category
+	if category == "" {
+		category = "custom"
+	}
+	return Rule{
+		ID:          c.ID,
+		Language:    lang.Name,
+		Category:    category,
+		Title:       title,
+		Description: c.Description,
+		Message:     message,
+		Severity:    severity,
+		Inspect: func(src []byte, grammar *sitter.Language, _ Options) ([]Ident, error) {
+			return Query(src, grammar, c.Query, c.Capture, condition)
+		},
+	}, nil
+}
+
+// condition builds the check a captured node has to pass to be reported.
+func (c CustomRule) condition() (func(string) bool, error) {
+	var mustMatch, mustNotMatch *regexp.Regexp
+	var err error
+	if c.MustMatch != "" {
+		if mustMatch, err = regexp.Compile(c.MustMatch); err != nil {
+			return nil, fmt.Errorf("custom rule %s has an invalid must-match pattern: %w", c.ID, err)
+		}
+	}
+	if c.MustNotMatch != "" {
+		if mustNotMatch, err = regexp.Compile(c.MustNotMatch); err != nil {
+			return nil, fmt.Errorf("custom rule %s has an invalid must-not-match pattern: %w", c.ID, err)
+		}
+	}
+	return func(ident string) bool {
+		if mustMatch != nil && !mustMatch.MatchString(ident) {
+			return true
+		}
+		if mustNotMatch != nil && mustNotMatch.MatchString(ident) {
+			return true
+		}
+		return mustMatch == nil && mustNotMatch == nil
+	}, nil
+}
+
+func hasCapture(q *sitter.Query, name string) bool {
+	for i := uint32(0); i < q.CaptureCount(); i++ {
+		if q.CaptureNameForId(i) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package inspect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/smacker/go-tree-sitter/golang"
+)
+
+var goLanguage = Language{Name: "go", Extensions: []string{".go"}, Grammar: golang.GetLanguage()}
+
+func TestCustomRuleCompile(t *testing.T) {
+	src := []byte("package main\n\nfunc newThing() {}\n\nfunc NewThing() {}\n\nvar newVar = 1\n")
+	rule, err := CustomRule{
+		ID:           "go-no-new-prefix",
+		Query:        "(function_declaration name: (identifier) @name) (var_spec name: (identifier) @var)",
+		Capture:      "name",
+		MustNotMatch: "^new",
+		Message:      "The function %s starts with new.",
+		Severity:     "warning",
+	}.Compile(goLanguage)
+	if err != nil {
+		t.Fatalf("failed to compile custom rule: %s", err)
+	}
+	result, err := File(src, "main.go", goLanguage, []Rule{rule})
+	if err != nil {
+		t.Fatalf("failed to run custom rule: %s", err)
+	}
+	annotations := result.Annotations
+	if len(annotations) != 1 {
+		t.Fatalf("expected a single annotation, returned %+v", annotations)
+	}
+	a := annotations[0]
+	if a.Title != "go-no-new-prefix" || a.Message != "The function newThing starts with new." || a.Type != "warning" || a.StartLine != 3 {
+		t.Errorf("unexpected annotation: %+v", a)
+	}
+}
+
+func TestCustomRuleCompileErrors(t *testing.T) {
+	valid := CustomRule{ID: "rule", Query: "(identifier) @name", Message: "%s"}
+	tests := map[string]func(r *CustomRule){
+		"missing id":      func(r *CustomRule) { r.ID = "" },
+		"upper case id":   func(r *CustomRule) { r.ID = "MyRule" },
+		"invalid query":   func(r *CustomRule) { r.Query = "(not_a_node)" },
+		"unknown capture": func(r *CustomRule) { r.Capture = "other" },
+		"invalid regex":   func(r *CustomRule) { r.MustMatch = "(" },
+		"missing message": func(r *CustomRule) { r.Message = "" },
+		"extra verbs":     func(r *CustomRule) { r.Message = "%s %d" },
+		"severity":        func(r *CustomRule) { r.Severity = "fatal" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule := valid
+			mutate(&rule)
+			if _, err := rule.Compile(goLanguage); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestCustomRuleMessageWithoutVerb(t *testing.T) {
+	rule, err := CustomRule{ID: "rule", Query: "(package_identifier) @name", Message: "100%% wrong"}.Compile(goLanguage)
+	if err != nil {
+		t.Fatalf("failed to compile custom rule: %s", err)
+	}
+	result, err := File([]byte("package main\n"), "main.go", goLanguage, []Rule{rule})
+	if err != nil {
+		t.Fatalf("failed to run custom rule: %s", err)
+	}
+	var messages []string
+	for _, a := range result.Annotations {
+		messages = append(messages, a.Message)
+	}
+	if expected := []string{"100% wrong"}; !reflect.DeepEqual(expected, messages) {
+		t.Errorf("expected %q, returned %q", expected, messages)
+	}
+}
@@ -1 +1,189 @@
 package inspect
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/git"
+)
+
+// Language is a tree-sitter grammar and the source files it parses.
+type Language struct {
+	Name       string
+	Extensions []string
+	Grammar    *sitter.Language
+	// Dialects holds the grammars of extensions Grammar can't parse, like
+	// TSX in TypeScript, keyed by extension.
+	Dialects map[string]*sitter.Language
+}
+
+// Matches reports whether fileName is written in the language.
+func (l Language) Matches(fileName string) bool {
+	for _, ext := range l.Extensions {
+		if strings.HasSuffix(fileName, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// GrammarFor returns the grammar fileName is parsed with.
+func (l Language) GrammarFor(fileName string) *sitter.Language {
+	for ext, grammar := range l.Dialects {
+		if strings.HasSuffix(fileName, ext) {
+			return grammar
+		}
+	}
+	return l.Grammar
+}
+
+// Grammars returns Grammar followed by the grammars of the dialects.
+func (l Language) Grammars() []*sitter.Language {
+	exts := make([]string, 0, len(l.Dialects))
+	for ext := range l.Dialects {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	grammars := []*sitter.Language{l.Grammar}
+	for _, ext := range exts {
+		grammars = append(grammars, l.Dialects[ext])
+	}
+	return grammars
+}
+
+// InspectFunc finds the identifiers in src, parsed with grammar, that break
+// a rule configured with opts.
+type InspectFunc func(src []byte, grammar *sitter.Language, opts Options) ([]Ident, error)
+
+// Example shows code that breaks a rule next to code that follows it.
+type Example struct {
+	Bad  string
+	Good string
+}
+
+// Rule is a single convention enforced on source files.
+type Rule struct {
+	// ID identifies the rule in suppressions, configuration and reports
+	// and must never change once released.
+	ID string
+	// Language is the name of the Language the rule applies to. Rules
+	// about citk itself, like the suppression rules, have no language.
+	Language    string
+	Category    string
+	Title       string
+	Description string
+	Examples    []Example
+	// Message is a format string with a single %s verb that is replaced by
+	// the offending identifier. Suggested replacements are appended to it.
+	Message string
+	// Severity is the type of the annotations the rule produces.
+	Severity annotation.AnnotationType
+	// Options declares the settings the rule accepts in the config file.
+	Options []Option
+	Inspect InspectFunc
+
+	// Disabled and Config are set from the config file by Configure.
+	Disabled bool
+	Config   Options
+}
+
+// Result holds the annotations produced by running rules.
+type Result struct {
+	Annotations []annotation.Annotation
+	// Suppressed are the findings silenced by suppression comments, kept so
+	// reports can account for them.
+	Suppressed []Suppressed
+}
+
+// Suppressed is a finding silenced by a suppression comment.
+type Suppressed struct {
+	annotation.Annotation
+	// Reason is the justification given by the suppression comment.
+	Reason string
+}
+
+// Add appends the annotations and suppressed findings of other to r.
+func (r *Result) Add(other Result) {
+	r.Annotations = append(r.Annotations, other.Annotations...)
+	r.Suppressed = append(r.Suppressed, other.Suppressed...)
+}
+
+// Run runs rules against the files in changes written in lang and returns
+// the annotations that fall on lines in scope.
+func Run(srcDir string, changes git.Changes, lang Language, rules []Rule) (Result, error) {
+	var result Result
+	for _, path := range changes.Paths() {
+		if !lang.Matches(path) {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(srcDir, filepath.FromSlash(path)))
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to read file: %w", err)
+		}
+		fileResult, err := File(src, filepath.FromSlash(path), lang, rules)
+		if err != nil {
+			return Result{}, err
+		}
+		for _, a := range fileResult.Annotations {
+			if changes.Contains(path, a.StartLine) {
+				result.Annotations = append(result.Annotations, a)
+			}
+		}
+		for _, s := range fileResult.Suppressed {
+			if changes.Contains(path, s.StartLine) {
+				result.Suppressed = append(result.Suppressed, s)
+			}
+		}
+	}
+	return result, nil
+}
+
+// File runs rules against src, read from fileName, and returns the
+// annotations left after applying the suppression comments in src along
+// with the findings they suppressed.
+func File(src []byte, fileName string, lang Language, rules []Rule) (Result, error) {
+	grammar := lang.GrammarFor(fileName)
+	root, err := sitter.ParseCtx(context.Background(), src, grammar)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to parse source code: %w", err)
+	}
+	suppressions := ParseSuppressions(root, src)
+
+	var result Result
+	for _, rule := range rules {
+		idents, err := rule.Inspect(src, grammar, rule.options())
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to run inspector %s on src file: %w", rule.ID, err)
+		}
+		for _, ident := range idents {
+			message := fmt.Sprintf(rule.Message, ident.Name)
+			if ident.Suggestion != "" {
+				message += fmt.Sprintf(" Consider renaming it to %s.", ident.Suggestion)
+			}
+			a := annotation.Annotation{
+				FileName:  fileName,
+				RuleID:    rule.ID,
+				Title:     rule.Title,
+				Message:   message,
+				Type:      rule.Severity,
+				StartLine: ident.Line,
+				EndLine:   ident.EndLine,
+				StartCol:  ident.Col,
+				EndCol:    ident.EndCol,
+			}
+			if reason, ok := suppressions.Suppress(rule.ID, ident.Line); ok {
+				result.Suppressed = append(result.Suppressed, Suppressed{Annotation: a, Reason: reason})
+				continue
+			}
+			result.Annotations = append(result.Annotations, a)
+		}
+	}
+	result.Annotations = append(result.Annotations, suppressions.Findings(fileName)...)
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Ident is a node captured by a query, usually an identifier. Lines and
+// columns are 1-based and columns count Unicode code points, not bytes, so
+// non-ASCII identifiers line up in editors. EndCol is exclusive.
+type Ident struct {
+	Name    string
+	Line    uint32
+	EndLine uint32
+	Col     uint32
+	EndCol  uint32
+	// Suggestion is a name that follows the rule the identifier breaks,
+	// when the inspector can tell.
+	Suggestion string
+}
+
+// Query parses src with lang, runs query against it and returns the nodes
+// captured as capture for which condition holds. An empty capture considers
+// every capture of the query.
+func Query(src []byte, lang *sitter.Language, query, capture string, condition func(ident string) bool) ([]Ident, error) {
+	return QueryNodes(src, lang, query, capture, func(n *sitter.Node) bool {
+		return condition(n.Content(src))
+	})
+}
+
+// QueryNodes is like Query, but condition is passed the captured node so it
+// can take the surrounding syntax tree into account.
+func QueryNodes(src []byte, lang *sitter.Language, query, capture string, condition func(n *sitter.Node) bool) ([]Ident, error) {
+	var decls []Ident
+
+	// Parse source code
+	n, err := sitter.ParseCtx(context.Background(), src, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source code: %w", err)
+	}
+	// Execute the query
+	q, err := sitter.NewQuery([]byte(query), lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a query for lang: %w", err)
+	}
+	qc := sitter.NewQueryCursor()
+	qc.Exec(q, n)
+	// Iterate over query results
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+		// Apply predicates filtering
+		m = qc.FilterPredicates(m, src)
+		for _, c := range m.Captures {
+			if capture != "" && q.CaptureNameForId(c.Index) != capture {
+				continue
+			}
+			if condition(c.Node) {
+				decls = append(decls, Ident{
+					Name:    c.Node.Content(src),
+					Line:    c.Node.StartPoint().Row + 1,
+					EndLine: c.Node.EndPoint().Row + 1,
+					Col:     column(src, c.Node.StartByte(), c.Node.StartPoint()),
+					EndCol:  column(src, c.Node.EndByte(), c.Node.EndPoint()),
+				})
+			}
+		}
+	}
+	return decls, nil
+}
+
+// column converts the 0-based byte column of point, found at offset in src,
+// to a 1-based column counting code points.
+func column(src []byte, offset uint32, point sitter.Point) uint32 {
+	lineStart := offset - point.Column
+	return uint32(utf8.RuneCount(src[lineStart:offset])) + 1
+}
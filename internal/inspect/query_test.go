@@ -0,0 +1,24 @@
+package inspect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryColumns(t *testing.T) {
+	src := []byte("package p\n\nvar héllo_wörld, x_y = 1, 2\n")
+	idents, err := Query(src, goLanguage.Grammar, "(var_spec (identifier) @var)", "", func(string) bool { return true })
+	if err != nil {
+		t.Fatalf("returned an error: %s", err)
+	}
+
+	// columns are 1-based and count code points, not bytes
+	expected := []Ident{
+		{Name: "héllo_wörld", Line: 3, EndLine: 3, Col: 5, EndCol: 16},
+		{Name: "x_y", Line: 3, EndLine: 3, Col: 18, EndCol: 21},
+	}
+
+	if !reflect.DeepEqual(expected, idents) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, idents)
+	}
+}
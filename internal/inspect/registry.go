@@ -0,0 +1,71 @@
+package inspect
+
+import (
+	"fmt"
+	"sort"
+)
+
+// registry holds every known rule in registration order.
+var registry []Rule
+
+// Register adds rule to the registry. Rule IDs are stable identifiers that
+// suppressions, configuration and reports refer to, so they have to be
+// unique.
+func Register(rule Rule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("rule %q has no ID", rule.Title)
+	}
+	if _, ok := Lookup(rule.ID); ok {
+		return fmt.Errorf("rule ID %s is already registered", rule.ID)
+	}
+	registry = append(registry, rule)
+	return nil
+}
+
+// MustRegister registers rules and panics if any of them can't be
+// registered. It is meant to be called from init functions.
+func MustRegister(rules ...Rule) {
+	for _, rule := range rules {
+		if err := Register(rule); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Lookup returns the rule registered under id.
+func Lookup(id string) (Rule, bool) {
+	if i := indexOf(id); i >= 0 {
+		return registry[i], true
+	}
+	return Rule{}, false
+}
+
+func indexOf(id string) int {
+	for i, rule := range registry {
+		if rule.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Rules returns every registered rule sorted by ID.
+func Rules() []Rule {
+	rules := append([]Rule(nil), registry...)
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// LanguageRules returns the enabled rules registered for the named language
+// in registration order.
+func LanguageRules(language string) []Rule {
+	var rules []Rule
+	for _, rule := range registry {
+		if rule.Language == language && !rule.Disabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
@@ -0,0 +1,30 @@
+package inspect
+
+import "testing"
+
+func TestRegister(t *testing.T) {
+	defer func(saved []Rule) { registry = saved }(registry)
+	registry = nil
+
+	if err := Register(Rule{ID: "b-rule", Language: "go"}); err != nil {
+		t.Fatalf("failed to register rule: %s", err)
+	}
+	if err := Register(Rule{ID: "a-rule", Language: "python"}); err != nil {
+		t.Fatalf("failed to register rule: %s", err)
+	}
+	if err := Register(Rule{ID: "b-rule"}); err == nil {
+		t.Errorf("expected an error registering a duplicate ID")
+	}
+	if err := Register(Rule{Title: "no ID"}); err == nil {
+		t.Errorf("expected an error registering a rule without an ID")
+	}
+	if rule, ok := Lookup("a-rule"); !ok || rule.Language != "python" {
+		t.Errorf("Lookup(a-rule) = %+v, %t", rule, ok)
+	}
+	if rules := Rules(); len(rules) != 2 || rules[0].ID != "a-rule" {
+		t.Errorf("expected rules sorted by ID, returned %+v", rules)
+	}
+	if rules := LanguageRules("go"); len(rules) != 1 || rules[0].ID != "b-rule" {
+		t.Errorf("expected only the go rule, returned %+v", rules)
+	}
+}
@@ -0,0 +1,195 @@
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+// Rules for the findings reported about suppression comments themselves.
+// They are produced by the engine rather than by a query, so they have no
+// InspectFunc.
+var (
+	UnusedSuppression = Rule{
+		ID:          "citk-unused-suppression",
+		Category:    "suppression",
+		Title:       "Unused suppression comment",
+		Description: "A citk:ignore comment that doesn't suppress any finding is stale and should be removed.",
+		Examples: []Example{{
+			Bad:  "//citk:ignore go-func-naming -- legacy API\nfunc parseURL() {}",
+			Good: "func parseURL() {}",
+		}},
+		Severity: annotation.Warning,
+	}
+	MissingReason = Rule{
+		ID:          "citk-suppression-reason",
+		Category:    "suppression",
+		Title:       "Suppression comment without a reason",
+		Description: "Every citk:ignore comment has to explain after a \"--\" why the finding it suppresses is justified.",
+		Examples: []Example{{
+			Bad:  "//citk:ignore go-func-naming\nfunc parse_url() {}",
+			Good: "//citk:ignore go-func-naming -- called from cgo\nfunc parse_url() {}",
+		}},
+		Severity: annotation.Warning,
+	}
+)
+
+func init() {
+	MustRegister(UnusedSuppression, MissingReason)
+}
+
+// directiveRE matches the text of a comment, with the comment markers
+// already stripped, that suppresses findings.
+var directiveRE = regexp.MustCompile(`^citk:(ignore-file|ignore)(?:\s+(.*))?$`)
+
+// Suppression is a single citk:ignore or citk:ignore-file comment.
+//
+//	//citk:ignore <rule-id> -- <reason>
+//
+// A citk:ignore comment trailing code suppresses findings of the rule on its
+// own line, while one on a line of its own suppresses findings on the next
+// line. A citk:ignore-file comment suppresses findings of the rule anywhere
+// in the file.
+type Suppression struct {
+	RuleID   string
+	Reason   string
+	File     bool
+	Line     uint32
+	EndLine  uint32
+	Col      uint32
+	EndCol   uint32
+	trailing bool
+	used     bool
+}
+
+func (s *Suppression) covers(ruleID string, line uint32) bool {
+	if s.RuleID == "" || s.RuleID != ruleID {
+		return false
+	}
+	switch {
+	case s.File:
+		return true
+	case s.trailing:
+		return line == s.Line
+	default:
+		return line == s.EndLine+1
+	}
+}
+
+// Suppressions are the suppression comments found in a single file.
+type Suppressions []*Suppression
+
+// ParseSuppressions collects the suppression comments from the tree rooted
+// at root, which was parsed from src.
+func ParseSuppressions(root *sitter.Node, src []byte) Suppressions {
+	var suppressions Suppressions
+	walk(root, func(n *sitter.Node) {
+		// comment node names differ between grammars, e.g. comment,
+		// line_comment and block_comment.
+		if !strings.HasSuffix(n.Type(), "comment") {
+			return
+		}
+		text := stripCommentMarkers(n.Content(src))
+		m := directiveRE.FindStringSubmatch(text)
+		if m == nil {
+			return
+		}
+		ruleID, reason, _ := strings.Cut(m[2], "--")
+		lineStart := bytes.LastIndexByte(src[:n.StartByte()], '\n') + 1
+		suppressions = append(suppressions, &Suppression{
+			RuleID:   strings.TrimSpace(ruleID),
+			Reason:   strings.TrimSpace(reason),
+			File:     m[1] == "ignore-file",
+			Line:     n.StartPoint().Row + 1,
+			EndLine:  n.EndPoint().Row + 1,
+			Col:      column(src, n.StartByte(), n.StartPoint()),
+			EndCol:   column(src, n.EndByte(), n.EndPoint()),
+			trailing: len(bytes.TrimSpace(src[lineStart:n.StartByte()])) > 0,
+		})
+	})
+	return suppressions
+}
+
+// Suppress reports whether a finding of ruleID on line is suppressed, and
+// the reason given for it, and marks the suppressions covering it as used.
+func (s Suppressions) Suppress(ruleID string, line uint32) (reason string, suppressed bool) {
+	for _, suppression := range s {
+		if suppression.covers(ruleID, line) {
+			suppression.used = true
+			suppressed = true
+			if reason == "" {
+				reason = suppression.Reason
+			}
+		}
+	}
+	return reason, suppressed
+}
+
+// Findings returns annotations for the suppressions in fileName that didn't
+// suppress anything or don't give a reason. It must only be called once
+// every rule has been run against the file. Suppressions of disabled rules
+// never count as unused.
+func (s Suppressions) Findings(fileName string) []annotation.Annotation {
+	unused, _ := Lookup(UnusedSuppression.ID)
+	missingReason, _ := Lookup(MissingReason.ID)
+
+	var annotations []annotation.Annotation
+	for _, suppression := range s {
+		name := suppression.RuleID
+		if name == "" {
+			name = "<missing rule ID>"
+		}
+		a := annotation.Annotation{
+			FileName:  fileName,
+			StartLine: suppression.Line,
+			EndLine:   suppression.EndLine,
+			StartCol:  suppression.Col,
+			EndCol:    suppression.EndCol,
+		}
+		if suppressed, ok := Lookup(suppression.RuleID); ok && suppressed.Disabled {
+			suppression.used = true
+		}
+		if !suppression.used && !unused.Disabled {
+			a.RuleID = unused.ID
+			a.Title = unused.Title
+			a.Type = unused.Severity
+			a.Message = fmt.Sprintf("The suppression of %s doesn't match any finding. Please remove it.", name)
+			annotations = append(annotations, a)
+		}
+		if suppression.Reason == "" && !missingReason.Disabled {
+			a.RuleID = missingReason.ID
+			a.Title = missingReason.Title
+			a.Type = missingReason.Severity
+			a.Message = fmt.Sprintf("The suppression of %s doesn't give a reason. Please explain why it is needed after a \"--\".", name)
+			annotations = append(annotations, a)
+		}
+	}
+	return annotations
+}
+
+// stripCommentMarkers removes the comment delimiters of the common grammars
+// from text.
+func stripCommentMarkers(text string) string {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(text, "//"):
+		text = strings.TrimPrefix(text, "//")
+	case strings.HasPrefix(text, "#"):
+		text = strings.TrimPrefix(text, "#")
+	case strings.HasPrefix(text, "/*"):
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	}
+	return strings.TrimSpace(text)
+}
+
+// walk calls fn for n and all of its descendants in document order.
+func walk(n *sitter.Node, fn func(*sitter.Node)) {
+	fn(n)
+	for i := 0; i < int(n.ChildCount()); i++ {
+		walk(n.Child(i), fn)
+	}
+}
@@ -0,0 +1,280 @@
+package python
+
+import (
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// Ident is an identifier found by one of the Python inspectors.
+type Ident = inspect.Ident
+
+// Language describes Python source files to the inspection engine.
+var Language = inspect.Language{
+	Name:       "python",
+	Extensions: []string{".py"},
+	Grammar:    python.GetLanguage(),
+}
+
+var (
+	// Leading and trailing underscores mark private and dunder names and
+	// are allowed by every style.
+	snakeCaseRE = regexp.MustCompile(`^_*[a-z][a-z0-9]*(_+[a-z0-9]+)*_*$`)
+	upperCaseRE = regexp.MustCompile(`^_*[A-Z][A-Z0-9]*(_+[A-Z0-9]+)*_*$`)
+	capWordsRE  = regexp.MustCompile(`^_*[A-Z][a-zA-Z0-9]*$`)
+
+	finalRE     = regexp.MustCompile(`^(typing\.)?Final(\[.*\])?$`)
+	typeAliasRE = regexp.MustCompile(`^(typing\.)?TypeAlias$`)
+)
+
+// typeFactories are the functions of the typing module that create types,
+// which are named in CapWords.
+var typeFactories = map[string]bool{
+	"NamedTuple":   true,
+	"NewType":      true,
+	"ParamSpec":    true,
+	"TypedDict":    true,
+	"TypeVar":      true,
+	"TypeVarTuple": true,
+}
+
+// builtinTypes are the builtin types that type aliases may be built from,
+// like Number = int | float.
+var builtinTypes = map[string]bool{
+	"bool": true, "bytes": true, "complex": true, "dict": true, "float": true,
+	"frozenset": true, "int": true, "list": true, "object": true, "set": true,
+	"str": true, "tuple": true, "type": true,
+}
+
+// genericTypes are the types that are subscripted in type aliases, like
+// Vector = list[float] or MaybeInt = Optional[int].
+var genericTypes = map[string]bool{
+	"dict": true, "frozenset": true, "list": true, "set": true, "tuple": true,
+	"type": true, "Annotated": true, "AsyncIterator": true, "Awaitable": true,
+	"Callable": true, "ChainMap": true, "ClassVar": true, "Collection": true,
+	"Container": true, "Coroutine": true, "Counter": true, "DefaultDict": true,
+	"Deque": true, "Dict": true, "FrozenSet": true, "Generator": true,
+	"Iterable": true, "Iterator": true, "List": true, "Literal": true,
+	"Mapping": true, "MutableMapping": true, "MutableSequence": true,
+	"MutableSet": true, "Optional": true, "OrderedDict": true, "Sequence": true,
+	"Set": true, "Tuple": true, "Type": true, "Union": true,
+}
+
+func isSnakeCase(ident string) bool {
+	return strings.Trim(ident, "_") == "" || snakeCaseRE.MatchString(ident)
+}
+
+func isUpperCase(ident string) bool {
+	return upperCaseRE.MatchString(ident)
+}
+
+func isCapWords(ident string) bool {
+	return capWordsRE.MatchString(ident)
+}
+
+// scope returns the type of the innermost function_definition,
+// class_definition or module node n is declared in.
+func scope(n *sitter.Node) string {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		switch t := p.Type(); t {
+		case "function_definition", "class_definition", "module":
+			return t
+		}
+	}
+	return "module"
+}
+
+func anomalousFuncDefs(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterFuncDefs := `(
+		(function_definition name: (identifier) @func)
+	)`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isSnakeCase(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterFuncDefs, "", check)
+}
+
+func anomalousClassDefs(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterClassDefs := `(
+		(class_definition name: (identifier) @class)
+	)`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isCapWords(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterClassDefs, "", check)
+}
+
+func anomalousVarAssignments(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterAssignments := `(
+		(assignment left: [
+			(identifier) @var
+			(pattern_list (identifier) @var)
+			(tuple_pattern (identifier) @var)
+		])
+	)`
+	checkGlobal := inspect.WithExceptions(func(ident string) bool {
+		return !isSnakeCase(ident) && !isUpperCase(ident)
+	}, opts)
+	// locals are never constants
+	checkLocal := inspect.WithExceptions(func(ident string) bool {
+		return !isSnakeCase(ident)
+	}, opts)
+	return inspect.QueryNodes(src, grammar, filterAssignments, "", func(n *sitter.Node) bool {
+		// constants are checked by anomalousConstAssignments
+		if n.Parent().Type() == "assignment" && isFinal(n.Parent(), src) && scope(n) == "module" {
+			return false
+		}
+		// type aliases and type variables use CapWords like classes
+		if n.Parent().Type() == "assignment" && scope(n) == "module" && isCapWords(n.Content(src)) && isTypeAlias(n.Parent(), src) {
+			return false
+		}
+		if scope(n) == "function_definition" {
+			return checkLocal(n.Content(src))
+		}
+		return checkGlobal(n.Content(src))
+	})
+}
+
+func anomalousConstAssignments(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterConstants := `(
+		(assignment left: (identifier) @constant type: (type))
+	)`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isUpperCase(ident)
+	}, opts)
+	return inspect.QueryNodes(src, grammar, filterConstants, "constant", func(n *sitter.Node) bool {
+		return scope(n) == "module" && isFinal(n.Parent(), src) && check(n.Content(src))
+	})
+}
+
+// isFinal reports whether assignment is annotated with typing.Final, which
+// PEP 591 uses to mark constants.
+func isFinal(assignment *sitter.Node, src []byte) bool {
+	t := assignment.ChildByFieldName("type")
+	return t != nil && finalRE.MatchString(t.Content(src))
+}
+
+// isTypeAlias reports whether assignment defines a type: it is annotated
+// with TypeAlias, calls one of the typeFactories or assigns a type
+// expression like SomeClass, Optional[int] or int | None.
+func isTypeAlias(assignment *sitter.Node, src []byte) bool {
+	if t := assignment.ChildByFieldName("type"); t != nil && typeAliasRE.MatchString(t.Content(src)) {
+		return true
+	}
+	right := assignment.ChildByFieldName("right")
+	if right == nil {
+		return false
+	}
+	if right.Type() == "call" {
+		return typeFactories[lastName(right.ChildByFieldName("function"), src)]
+	}
+	return isTypeExpr(right, src)
+}
+
+func isTypeExpr(n *sitter.Node, src []byte) bool {
+	switch n.Type() {
+	case "identifier", "attribute":
+		// UPPER_CASE names are constants rather than classes
+		name := lastName(n, src)
+		return builtinTypes[name] || isCapWords(name) && !isUpperCase(name)
+	case "subscript":
+		// only generic types are subscripted in type expressions, items[0]
+		// is a value
+		return genericTypes[lastName(n.ChildByFieldName("value"), src)]
+	case "none":
+		return true
+	case "binary_operator":
+		op := n.ChildByFieldName("operator")
+		left, right := n.ChildByFieldName("left"), n.ChildByFieldName("right")
+		return op != nil && op.Type() == "|" && left != nil && right != nil && isTypeExpr(left, src) && isTypeExpr(right, src)
+	default:
+		return false
+	}
+}
+
+// lastName returns the name an identifier or attribute, like typing.TypeVar,
+// ends with.
+func lastName(n *sitter.Node, src []byte) string {
+	if n == nil {
+		return ""
+	}
+	if n.Type() == "attribute" {
+		n = n.ChildByFieldName("attribute")
+		if n == nil {
+			return ""
+		}
+	}
+	return n.Content(src)
+}
+
+// Rules lists the PEP 8 naming conventions checked in Python source files.
+var Rules = []inspect.Rule{
+	{
+		ID:          "py-class-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Class name not following PEP 8",
+		Description: "Class names use the CapWords convention.",
+		Examples: []inspect.Example{{
+			Bad:  "class http_client:\n    pass",
+			Good: "class HTTPClient:\n    pass",
+		}},
+		Message:  "The declaration of the class %s is not following PEP 8. Class names use CapWords.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousClassDefs,
+	},
+	{
+		ID:          "py-constant-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Constant name not following PEP 8",
+		Description: "Module level constants are written in capital letters with underscores separating words. Only names annotated with typing.Final are checked, as Python can't tell an unannotated constant like max_retries = 3 from a module level variable.",
+		Examples: []inspect.Example{{
+			Bad:  "max_retries: Final = 3",
+			Good: "MAX_RETRIES: Final = 3",
+		}},
+		Message:  "The declaration of the constant %s is not following PEP 8. Constants use UPPER_CASE.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousConstAssignments,
+	},
+	{
+		ID:          "py-function-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Function name not following PEP 8",
+		Description: "Function and method names are lowercase, with words separated by underscores.",
+		Examples: []inspect.Example{{
+			Bad:  "def parseConfig(path):\n    ...",
+			Good: "def parse_config(path):\n    ...",
+		}},
+		Message:  "The declaration of the function %s is not following PEP 8. Function names use snake_case.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousFuncDefs,
+	},
+	{
+		ID:          "py-variable-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Variable name not following PEP 8",
+		Description: "Variable names are lowercase, with words separated by underscores. Module and class level names may also be UPPER_CASE constants, and module level type aliases and type variables, like UserId = NewType(\"UserId\", int), use CapWords.",
+		Examples: []inspect.Example{{
+			Bad:  "def main():\n    retryCount = 0",
+			Good: "def main():\n    retry_count = 0",
+		}},
+		Message:  "The variable %s is not following PEP 8. Variable names use snake_case.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousVarAssignments,
+	},
+}
+
+func init() {
+	inspect.MustRegister(Rules...)
+}
@@ -0,0 +1,105 @@
+package python
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAnomalousFuncDefs(t *testing.T) {
+	file, err := os.ReadFile("./testdata/functions.py")
+	if err != nil {
+		t.Fatalf("failed to open testdata/functions.py: %s", err.Error())
+	}
+	funcs, err := anomalousFuncDefs(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "camelCaseFunction", Line: 5, EndLine: 5, Col: 5, EndCol: 22},
+		{Name: "PascalCaseFunction", Line: 9, EndLine: 9, Col: 5, EndCol: 23},
+		{Name: "badMethod", Line: 20, EndLine: 20, Col: 9, EndCol: 18},
+	}
+
+	if !reflect.DeepEqual(expected, funcs) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, funcs)
+	}
+}
+
+func TestAnomalousClassDefs(t *testing.T) {
+	file, err := os.ReadFile("./testdata/classes.py")
+	if err != nil {
+		t.Fatalf("failed to open testdata/classes.py: %s", err.Error())
+	}
+	classes, err := anomalousClassDefs(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "snake_case_class", Line: 13, EndLine: 13, Col: 7, EndCol: 23},
+		{Name: "Mixed_Case_Class", Line: 17, EndLine: 17, Col: 7, EndCol: 23},
+	}
+
+	if !reflect.DeepEqual(expected, classes) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, classes)
+	}
+}
+
+func TestAnomalousVarAssignments(t *testing.T) {
+	file, err := os.ReadFile("./testdata/variables.py")
+	if err != nil {
+		t.Fatalf("failed to open testdata/variables.py: %s", err.Error())
+	}
+	vars, err := anomalousVarAssignments(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "camelCaseVar", Line: 3, EndLine: 3, Col: 1, EndCol: 13},
+		{Name: "secondVar", Line: 4, EndLine: 4, Col: 8, EndCol: 17},
+		{Name: "classAttr", Line: 10, EndLine: 10, Col: 5, EndCol: 14},
+		{Name: "LOCAL_CONST", Line: 15, EndLine: 15, Col: 5, EndCol: 16},
+		{Name: "localVar", Line: 17, EndLine: 17, Col: 9, EndCol: 17},
+		// type aliases and type variables may use CapWords, other values not
+		{Name: "MaxRetries", Line: 27, EndLine: 27, Col: 1, EndCol: 11},
+		{Name: "MaxRetries", Line: 28, EndLine: 28, Col: 1, EndCol: 11},
+		{Name: "FirstItem", Line: 29, EndLine: 29, Col: 1, EndCol: 10},
+		{Name: "BadName", Line: 30, EndLine: 30, Col: 1, EndCol: 8},
+	}
+
+	if !reflect.DeepEqual(expected, vars) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, vars)
+	}
+}
+
+func TestAnomalousConstAssignments(t *testing.T) {
+	file, err := os.ReadFile("./testdata/constants.py")
+	if err != nil {
+		t.Fatalf("failed to open testdata/constants.py: %s", err.Error())
+	}
+	consts, err := anomalousConstAssignments(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "max_timeout", Line: 5, EndLine: 5, Col: 1, EndCol: 12},
+		{Name: "defaultName", Line: 6, EndLine: 6, Col: 1, EndCol: 12},
+	}
+
+	if !reflect.DeepEqual(expected, consts) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, consts)
+	}
+
+	// Constants are left to anomalousConstAssignments.
+	vars, err := anomalousVarAssignments(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+	if len(vars) != 0 {
+		t.Errorf("expected no variable findings, returned %+v", vars)
+	}
+}
@@ -0,0 +1,18 @@
+class CapWordsClass:
+    pass
+
+
+class HTTPServer:
+    pass
+
+
+class _PrivateClass:
+    pass
+
+
+class snake_case_class:
+    pass
+
+
+class Mixed_Case_Class:
+    pass
@@ -0,0 +1,11 @@
+from typing import Final
+import typing
+
+MAX_RETRIES: Final = 3
+max_timeout: Final[int] = 30
+defaultName: typing.Final = "citk"
+plain_annotated: int = 4
+
+
+def main():
+    local: Final = 1
@@ -0,0 +1,21 @@
+def snake_case_function():
+    pass
+
+
+def camelCaseFunction():
+    pass
+
+
+def PascalCaseFunction():
+    pass
+
+
+class Thing:
+    def __init__(self):
+        pass
+
+    def _private_method(self):
+        pass
+
+    def badMethod(self):
+        pass
@@ -0,0 +1,32 @@
+snake_case_var = 1
+UPPER_CASE_CONST = 2
+camelCaseVar = 3
+first, secondVar = 4, 5
+__all__ = []
+
+
+class Thing:
+    CLASS_CONST = 1
+    classAttr = 2
+
+
+def main():
+    local_var = 1
+    LOCAL_CONST = 2
+    if local_var:
+        localVar = 3
+
+
+UserId = NewType("UserId", int)
+T = TypeVar("T")
+P = typing.ParamSpec("P")
+Alias = SomeClass
+Vector = list[float]
+Number = int | float
+Json: TypeAlias = dict
+MaxRetries = 3
+MaxRetries = LIMIT
+FirstItem = items[0]
+BadName = 3
+MaybeInt = Optional[int]
+Handler = typing.Callable[[int], None]
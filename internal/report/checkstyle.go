@@ -0,0 +1,110 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     uint32 `xml:"line,attr"`
+	Column   uint32 `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// checkstyleSeverity maps an AnnotationType to a Checkstyle severity.
+func checkstyleSeverity(t annotation.AnnotationType) string {
+	switch t {
+	case annotation.Error:
+		return "error"
+	case annotation.Warning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Checkstyle writes the annotations to w as a Checkstyle XML report with a
+// <file> per file and an <error> per annotation, its source being the ID of
+// the rule.
+func Checkstyle(w io.Writer, annotations []annotation.Annotation) error {
+	report := checkstyleReport{Version: "4.3"}
+	for _, file := range groupByFile(annotations) {
+		f := checkstyleFile{Name: file.name}
+		for _, a := range file.annotations {
+			f.Errors = append(f.Errors, checkstyleError{
+				Line:     a.StartLine,
+				Column:   a.StartCol,
+				Severity: checkstyleSeverity(a.Type),
+				Message:  a.Message,
+				Source:   a.RuleID,
+			})
+		}
+		report.Files = append(report.Files, f)
+	}
+	return writeXML(w, report)
+}
+
+type fileAnnotations struct {
+	name        string
+	annotations []annotation.Annotation
+}
+
+// groupByFile groups annotations by file, sorting the files by name and the
+// annotations of each file by position.
+func groupByFile(annotations []annotation.Annotation) []fileAnnotations {
+	index := map[string]int{}
+	var files []fileAnnotations
+	for _, a := range annotations {
+		name := filepath.ToSlash(a.FileName)
+		i, ok := index[name]
+		if !ok {
+			i = len(files)
+			index[name] = i
+			files = append(files, fileAnnotations{name: name})
+		}
+		files[i].annotations = append(files[i].annotations, a)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+	for _, f := range files {
+		sort.SliceStable(f.annotations, func(i, j int) bool {
+			a, b := f.annotations[i], f.annotations[j]
+			if a.StartLine != b.StartLine {
+				return a.StartLine < b.StartLine
+			}
+			return a.StartCol < b.StartCol
+		})
+	}
+	return files
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
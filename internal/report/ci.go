@@ -0,0 +1,191 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+// Azure writes the annotations as Azure Pipelines logging commands, which
+// turn them into warnings and errors of the pipeline run. Azure Pipelines
+// has no notices, so they are reported as warnings.
+func Azure(w io.Writer, run Run) error {
+	var b strings.Builder
+	for _, a := range run.Result.Annotations {
+		if a.Type == annotation.Debug {
+			fmt.Fprintf(&b, "##[debug]%s\n", azureEscapeMessage(a.Message))
+			continue
+		}
+		issueType := "warning"
+		if a.Type == annotation.Error {
+			issueType = "error"
+		}
+		b.WriteString("##vso[task.logissue type=" + issueType + ";")
+		if a.FileName != "" {
+			b.WriteString("sourcepath=" + azureEscapeProperty(filepath.ToSlash(a.FileName)) + ";")
+		}
+		if a.StartLine != 0 {
+			fmt.Fprintf(&b, "linenumber=%d;", a.StartLine)
+		}
+		if a.StartCol != 0 {
+			fmt.Fprintf(&b, "columnnumber=%d;", a.StartCol)
+		}
+		if a.RuleID != "" {
+			b.WriteString("code=" + azureEscapeProperty(a.RuleID) + ";")
+		}
+		b.WriteString("]" + azureEscapeMessage(a.Message) + "\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var (
+	azureMessageReplacer  = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A")
+	azurePropertyReplacer = strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D")
+)
+
+func azureEscapeMessage(s string) string {
+	return azureMessageReplacer.Replace(s)
+}
+
+func azureEscapeProperty(s string) string {
+	return azurePropertyReplacer.Replace(s)
+}
+
+// TeamCity writes the annotations as TeamCity service messages, which list
+// them on the Inspections tab of the build. Every rule that produced an
+// annotation is declared as an inspection type first.
+func TeamCity(w io.Writer, run Run) error {
+	var b strings.Builder
+	declared := map[string]bool{}
+	for _, rule := range run.Rules {
+		if declared[rule.ID] || !hasRule(run.Result.Annotations, rule.ID) {
+			continue
+		}
+		declared[rule.ID] = true
+		category := rule.Category
+		if category == "" {
+			category = "citk"
+		}
+		fmt.Fprintf(&b, "##teamcity[inspectionType id='%s' name='%s' category='%s' description='%s']\n",
+			teamCityEscape(rule.ID), teamCityEscape(rule.Title), teamCityEscape(category), teamCityEscape(rule.Description))
+	}
+	for _, a := range run.Result.Annotations {
+		// TeamCity rejects inspections without a type
+		typeID := a.RuleID
+		if typeID == "" {
+			typeID = "citk"
+		}
+		// annotations of rules that aren't known still need a type
+		if !declared[typeID] {
+			declared[typeID] = true
+			fmt.Fprintf(&b, "##teamcity[inspectionType id='%s' name='%s' category='citk' description='%s']\n",
+				teamCityEscape(typeID), teamCityEscape(typeID), teamCityEscape(a.Title))
+		}
+		fmt.Fprintf(&b, "##teamcity[inspection typeId='%s' message='%s' file='%s'",
+			teamCityEscape(typeID), teamCityEscape(a.Message), teamCityEscape(filepath.ToSlash(a.FileName)))
+		if a.StartLine != 0 {
+			fmt.Fprintf(&b, " line='%d'", a.StartLine)
+		}
+		fmt.Fprintf(&b, " SEVERITY='%s']\n", teamCitySeverity(a.Type))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func hasRule(annotations []annotation.Annotation, ruleID string) bool {
+	for _, a := range annotations {
+		if a.RuleID == ruleID {
+			return true
+		}
+	}
+	return false
+}
+
+var teamCityReplacer = strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+func teamCityEscape(s string) string {
+	return teamCityReplacer.Replace(s)
+}
+
+// teamCitySeverity maps an AnnotationType to a TeamCity inspection severity.
+func teamCitySeverity(t annotation.AnnotationType) string {
+	switch t {
+	case annotation.Error:
+		return "ERROR"
+	case annotation.Warning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+// buildkiteAgent runs buildkite-agent with args and stdin. It is a variable
+// so tests can replace it.
+var buildkiteAgent = func(stdin io.Reader, args ...string) error {
+	cmd := exec.Command("buildkite-agent", args...)
+	cmd.Stdin = stdin
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to run buildkite-agent: %w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// Buildkite writes the annotations to w as file:line:col lines for the
+// build log and annotates the build with a Markdown summary of them through
+// buildkite-agent, styled after the most severe annotation. The build is
+// left alone when there is nothing to report.
+func Buildkite(w io.Writer, run Run) error {
+	for _, a := range run.Result.Annotations {
+		if _, err := fmt.Fprintln(w, plainLine(a)); err != nil {
+			return err
+		}
+	}
+	if len(run.Result.Annotations) == 0 {
+		return nil
+	}
+	var summary bytes.Buffer
+	if err := Summary(&summary, "", run.Result); err != nil {
+		return err
+	}
+	return buildkiteAgent(&summary, "annotate", "--style", buildkiteStyle(run.Result.Annotations), "--context", "citk")
+}
+
+// buildkiteStyle derives the style of a Buildkite annotation from the most
+// severe annotation.
+func buildkiteStyle(annotations []annotation.Annotation) string {
+	switch annotation.MaxSeverity(annotations) {
+	case annotation.Error:
+		return "error"
+	case annotation.Warning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// plainLine formats an annotation as a file:line:col: type: message line
+// that terminals and log viewers recognise.
+func plainLine(a annotation.Annotation) string {
+	var b strings.Builder
+	if a.FileName != "" {
+		b.WriteString(filepath.ToSlash(a.FileName))
+		if a.StartLine != 0 {
+			fmt.Fprintf(&b, ":%d", a.StartLine)
+			if a.StartCol != 0 {
+				fmt.Fprintf(&b, ":%d", a.StartCol)
+			}
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(string(a.Type) + ": " + a.Message)
+	if a.RuleID != "" {
+		b.WriteString(" (" + a.RuleID + ")")
+	}
+	return b.String()
+}
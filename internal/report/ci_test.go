@@ -0,0 +1,127 @@
+package report
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+func TestAzure(t *testing.T) {
+	run := Run{Result: inspect.Result{Annotations: []annotation.Annotation{
+		{
+			FileName:  "pkg/a;b].go",
+			RuleID:    "go-var-naming",
+			Message:   "100% bad\nvar",
+			StartLine: 3,
+			StartCol:  5,
+			Type:      annotation.Error,
+		},
+		{Message: "no position", Type: annotation.Notice},
+	}}}
+	var buf bytes.Buffer
+	if err := Azure(&buf, run); err != nil {
+		t.Fatalf("failed to write logging commands: %s", err)
+	}
+	expected := "##vso[task.logissue type=error;sourcepath=pkg/a%3Bb%5D.go;linenumber=3;columnnumber=5;code=go-var-naming;]100%AZP25 bad%0Avar\n" +
+		"##vso[task.logissue type=warning;]no position\n"
+	if buf.String() != expected {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, buf.String())
+	}
+}
+
+func TestTeamCity(t *testing.T) {
+	run := Run{
+		Rules: []inspect.Rule{
+			{ID: "go-var-naming", Category: "naming", Title: "Variable naming", Description: "Use [mixedCaps]"},
+			{ID: "go-func-naming", Category: "naming", Title: "Function naming"},
+		},
+		Result: inspect.Result{Annotations: []annotation.Annotation{
+			{FileName: "a.go", RuleID: "go-var-naming", Message: "it's bad|wrong", StartLine: 3, Type: annotation.Warning},
+			{FileName: "a.go", RuleID: "custom", Message: "line\nbreak", Type: annotation.Error},
+			{Message: "no rule", Type: annotation.Notice},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := TeamCity(&buf, run); err != nil {
+		t.Fatalf("failed to write service messages: %s", err)
+	}
+	expected := "##teamcity[inspectionType id='go-var-naming' name='Variable naming' category='naming' description='Use |[mixedCaps|]']\n" +
+		"##teamcity[inspection typeId='go-var-naming' message='it|'s bad||wrong' file='a.go' line='3' SEVERITY='WARNING']\n" +
+		"##teamcity[inspectionType id='custom' name='custom' category='citk' description='']\n" +
+		"##teamcity[inspection typeId='custom' message='line|nbreak' file='a.go' SEVERITY='ERROR']\n" +
+		"##teamcity[inspectionType id='citk' name='citk' category='citk' description='']\n" +
+		"##teamcity[inspection typeId='citk' message='no rule' file='' SEVERITY='INFO']\n"
+	if buf.String() != expected {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, buf.String())
+	}
+}
+
+func TestBuildkite(t *testing.T) {
+	var (
+		args    []string
+		summary []byte
+	)
+	defer func(agent func(io.Reader, ...string) error) { buildkiteAgent = agent }(buildkiteAgent)
+	buildkiteAgent = func(stdin io.Reader, a ...string) error {
+		args = a
+		var err error
+		summary, err = io.ReadAll(stdin)
+		return err
+	}
+	run := Run{Result: inspect.Result{Annotations: []annotation.Annotation{
+		{FileName: "a.go", RuleID: "go-var-naming", Message: "bad_var is bad", StartLine: 3, StartCol: 5, Type: annotation.Warning},
+	}}}
+	var buf bytes.Buffer
+	if err := Buildkite(&buf, run); err != nil {
+		t.Fatalf("failed to annotate build: %s", err)
+	}
+	if expected := "a.go:3:5: warning: bad_var is bad (go-var-naming)\n"; buf.String() != expected {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, buf.String())
+	}
+	expectedArgs := []string{"annotate", "--style", "warning", "--context", "citk"}
+	if !reflect.DeepEqual(expectedArgs, args) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expectedArgs, args)
+	}
+	if !bytes.Contains(summary, []byte("## citk")) {
+		t.Errorf("expected the annotation to be the Markdown summary, returned %s", summary)
+	}
+
+	// nothing is annotated without findings
+	args = nil
+	buf.Reset()
+	if err := Buildkite(&buf, Run{}); err != nil {
+		t.Fatalf("failed to annotate build: %s", err)
+	}
+	if args != nil || buf.Len() != 0 {
+		t.Errorf("expected no output and no annotation, returned %q and ran buildkite-agent %q", buf.String(), args)
+	}
+}
+
+func TestDetectReporter(t *testing.T) {
+	testCases := []struct {
+		env      map[string]string
+		terminal bool
+		expected string
+	}{
+		{env: map[string]string{}, expected: "github"},
+		{env: map[string]string{}, terminal: true, expected: "pretty"},
+		{env: map[string]string{"GITHUB_ACTIONS": "true"}, terminal: true, expected: "github"},
+		{env: map[string]string{"GITHUB_ACTIONS": "true"}, expected: "github"},
+		{env: map[string]string{"TF_BUILD": "True"}, expected: "azure"},
+		{env: map[string]string{"TEAMCITY_VERSION": "2023.05"}, expected: "teamcity"},
+		{env: map[string]string{"BUILDKITE": "true"}, expected: "buildkite"},
+	}
+	for _, tc := range testCases {
+		returned := DetectReporter(func(key string) string { return tc.env[key] }, tc.terminal)
+		if returned != tc.expected {
+			t.Errorf("expected and returned values do not match: expected %+v, returned %+v", tc.expected, returned)
+		}
+		if _, ok := LookupReporter(returned); !ok {
+			t.Errorf("expected reporter %s to be registered", returned)
+		}
+	}
+}
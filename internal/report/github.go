@@ -0,0 +1,83 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tjgurwara99/citk/internal/github"
+)
+
+// GitHubPullRequestReview posts the annotations of run as a review of the
+// pull request the GitHub Actions workflow runs for.
+func GitHubPullRequestReview(w io.Writer, run Run) error {
+	pr, err := github.PullRequestFromEnv()
+	if err != nil {
+		return fmt.Errorf("github-pr-review reporter: %w", err)
+	}
+	client := github.NewClient(gitHubAPIURL(run), os.Getenv("GITHUB_TOKEN"))
+	n, err := client.PostReview(pr, run.Result.Annotations)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Reported %d new finding(s) on pull request #%d\n", n, pr.Number)
+	return err
+}
+
+// GitHubCheckRun creates a check run with the annotations of run on the
+// commit the GitHub Actions workflow runs for, summarising them like the
+// job summary.
+func GitHubCheckRun(w io.Writer, run Run) error {
+	owner, repo, err := github.RepositoryFromEnv()
+	if err != nil {
+		return fmt.Errorf("github-check reporter: %w", err)
+	}
+	sha, err := github.HeadSHAFromEnv()
+	if err != nil {
+		return fmt.Errorf("github-check reporter: %w", err)
+	}
+	var summary strings.Builder
+	if err := Summary(&summary, GitHubBlobURL(sha), run.Result); err != nil {
+		return err
+	}
+	client := github.NewClient(gitHubAPIURL(run), os.Getenv("GITHUB_TOKEN"))
+	id, err := client.CreateCheckRun(github.CheckRun{
+		Owner:   owner,
+		Repo:    repo,
+		Name:    "citk",
+		HeadSHA: sha,
+		Title:   fmt.Sprintf("%d finding(s)", len(run.Result.Annotations)),
+		Summary: summary.String(),
+	}, run.Result.Annotations)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Created check run %d with %d annotation(s)\n", id, len(run.Result.Annotations))
+	return err
+}
+
+// gitHubAPIURL returns the API URL of run, falling back to the
+// GITHUB_API_URL set by GitHub Actions, which points at GitHub Enterprise
+// Server when needed.
+func gitHubAPIURL(run Run) string {
+	if run.GitHubAPIURL != "" {
+		return run.GitHubAPIURL
+	}
+	return os.Getenv("GITHUB_API_URL")
+}
+
+// GitHubBlobURL returns the URL of the files of the repository the GitHub
+// Actions workflow runs for at commit sha, or an empty string outside of
+// GitHub Actions.
+func GitHubBlobURL(sha string) string {
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	if repo == "" {
+		return ""
+	}
+	server := os.Getenv("GITHUB_SERVER_URL")
+	if server == "" {
+		server = "https://github.com"
+	}
+	return fmt.Sprintf("%s/%s/blob/%s", server, repo, sha)
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+type gitlabIssue struct {
+	Type        string         `json:"type"`
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Categories  []string       `json:"categories"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin uint32 `json:"begin"`
+	End   uint32 `json:"end,omitempty"`
+}
+
+// gitlabSeverity maps an AnnotationType to a Code Quality severity.
+func gitlabSeverity(t annotation.AnnotationType) string {
+	switch t {
+	case annotation.Error:
+		return "major"
+	case annotation.Warning:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// GitLab writes the annotations to w as a GitLab Code Quality report.
+//
+// Merge request widgets tell new issues from resolved ones by their
+// fingerprints, so they are derived from the finding rather than its
+// position. Repeated findings within a file get their occurrence mixed in
+// to keep the fingerprints unique.
+func GitLab(w io.Writer, annotations []annotation.Annotation) error {
+	issues := []gitlabIssue{}
+	fingerprints := annotation.UniqueFingerprints(annotations)
+	for i, a := range annotations {
+		issues = append(issues, gitlabIssue{
+			Type:        "issue",
+			Description: a.Message,
+			CheckName:   a.RuleID,
+			Fingerprint: fingerprints[i],
+			Severity:    gitlabSeverity(a.Type),
+			Categories:  []string{"Style"},
+			Location: gitlabLocation{
+				Path:  filepath.ToSlash(a.FileName),
+				Lines: gitlabLines{Begin: a.StartLine, End: a.EndLine},
+			},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
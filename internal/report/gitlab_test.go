@@ -0,0 +1,61 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+func TestGitLab(t *testing.T) {
+	a := annotation.Annotation{
+		FileName:  "pkg/a.go",
+		RuleID:    "go-var-naming",
+		Message:   "bad_var is bad",
+		StartLine: 3,
+		EndLine:   4,
+		Type:      annotation.Warning,
+	}
+	var buf bytes.Buffer
+	if err := GitLab(&buf, []annotation.Annotation{a, a}); err != nil {
+		t.Fatalf("failed to write Code Quality report: %s", err)
+	}
+	var issues []gitlabIssue
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("failed to decode Code Quality report: %s", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, returned %+v", issues)
+	}
+	expected := gitlabIssue{
+		Type:        "issue",
+		Description: "bad_var is bad",
+		CheckName:   "go-var-naming",
+		Fingerprint: a.Fingerprint(),
+		Severity:    "minor",
+		Categories:  []string{"Style"},
+		Location:    gitlabLocation{Path: "pkg/a.go", Lines: gitlabLines{Begin: 3, End: 4}},
+	}
+	if !reflect.DeepEqual(expected, issues[0]) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, issues[0])
+	}
+	if issues[1].Fingerprint == issues[0].Fingerprint {
+		t.Errorf("expected repeated findings to have unique fingerprints")
+	}
+
+	// fingerprints don't depend on the position
+	moved := a
+	moved.StartLine, moved.EndLine = 10, 10
+	buf.Reset()
+	if err := GitLab(&buf, []annotation.Annotation{moved}); err != nil {
+		t.Fatalf("failed to write Code Quality report: %s", err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("failed to decode Code Quality report: %s", err)
+	}
+	if issues[0].Fingerprint != expected.Fingerprint {
+		t.Errorf("expected the fingerprint to survive moving the finding")
+	}
+}
@@ -0,0 +1,143 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+// JSONSchemaVersion is the version of the JSON report format. It changes
+// whenever a field is removed or changes meaning, not when one is added.
+const JSONSchemaVersion = 1
+
+// JSONSchemaURL is the JSON Schema describing the JSON report format.
+const JSONSchemaURL = "https://raw.githubusercontent.com/tjgurwara99/citk/main/schema/report.v1.schema.json"
+
+type jsonReport struct {
+	Schema   string        `json:"$schema"`
+	Version  int           `json:"version"`
+	Tool     jsonTool      `json:"tool"`
+	Base     string        `json:"base"`
+	Head     string        `json:"head"`
+	Findings []jsonFinding `json:"findings"`
+}
+
+// jsonLine is a line of the JSONL report. Every line carries the details
+// of the run, so lines can be filtered and concatenated on their own.
+type jsonLine struct {
+	Version int         `json:"version"`
+	Tool    jsonTool    `json:"tool"`
+	Base    string      `json:"base"`
+	Head    string      `json:"head"`
+	Finding jsonFinding `json:"finding"`
+}
+
+type jsonTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type jsonFinding struct {
+	RuleID      string `json:"ruleId"`
+	Language    string `json:"language"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Message     string `json:"message"`
+	File        string `json:"file"`
+	StartLine   uint32 `json:"startLine"`
+	EndLine     uint32 `json:"endLine"`
+	StartColumn uint32 `json:"startColumn"`
+	EndColumn   uint32 `json:"endColumn"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// JSON writes the findings of run to w as a single JSON document described
+// by the schema at JSONSchemaURL. Findings are sorted by position, so the
+// reports of two runs can be diffed.
+func JSON(w io.Writer, run Run) error {
+	report := jsonReport{
+		Schema:   JSONSchemaURL,
+		Version:  JSONSchemaVersion,
+		Tool:     jsonTool{Name: "citk", Version: run.Version},
+		Base:     run.Base,
+		Head:     run.Head,
+		Findings: jsonFindings(run),
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// JSONL writes the findings of run to w as JSON lines, one per finding and
+// in the same order as JSON.
+func JSONL(w io.Writer, run Run) error {
+	enc := json.NewEncoder(w)
+	for _, finding := range jsonFindings(run) {
+		line := jsonLine{
+			Version: JSONSchemaVersion,
+			Tool:    jsonTool{Name: "citk", Version: run.Version},
+			Base:    run.Base,
+			Head:    run.Head,
+			Finding: finding,
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsonFindings(run Run) []jsonFinding {
+	languages := make(map[string]string, len(run.Rules))
+	for _, rule := range run.Rules {
+		languages[rule.ID] = rule.Language
+	}
+	annotations := sortAnnotations(run.Result.Annotations)
+	fingerprints := annotation.UniqueFingerprints(annotations)
+	findings := make([]jsonFinding, 0, len(annotations))
+	for i, a := range annotations {
+		findings = append(findings, jsonFinding{
+			RuleID:      a.RuleID,
+			Language:    languages[a.RuleID],
+			Type:        string(a.Type),
+			Title:       a.Title,
+			Message:     a.Message,
+			File:        filepath.ToSlash(a.FileName),
+			StartLine:   a.StartLine,
+			EndLine:     a.EndLine,
+			StartColumn: a.StartCol,
+			EndColumn:   a.EndCol,
+			Fingerprint: fingerprints[i],
+		})
+	}
+	return findings
+}
+
+// sortAnnotations returns a copy of annotations sorted by file, position,
+// rule and message, which orders them the same way in every run.
+func sortAnnotations(annotations []annotation.Annotation) []annotation.Annotation {
+	sorted := append([]annotation.Annotation(nil), annotations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch {
+		case a.FileName != b.FileName:
+			return filepath.ToSlash(a.FileName) < filepath.ToSlash(b.FileName)
+		case a.StartLine != b.StartLine:
+			return a.StartLine < b.StartLine
+		case a.StartCol != b.StartCol:
+			return a.StartCol < b.StartCol
+		case a.EndLine != b.EndLine:
+			return a.EndLine < b.EndLine
+		case a.EndCol != b.EndCol:
+			return a.EndCol < b.EndCol
+		case a.RuleID != b.RuleID:
+			return a.RuleID < b.RuleID
+		default:
+			return a.Message < b.Message
+		}
+	})
+	return sorted
+}
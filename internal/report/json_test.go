@@ -0,0 +1,147 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+func TestJSON(t *testing.T) {
+	run := Run{
+		Rules: []inspect.Rule{{ID: "go-var-naming", Language: "go"}},
+		Result: inspect.Result{Annotations: []annotation.Annotation{
+			{FileName: "b.go", RuleID: "go-var-naming", Message: "b", StartLine: 1, EndLine: 1, StartCol: 1, EndCol: 2, Type: annotation.Warning},
+			{FileName: "a.go", RuleID: "go-var-naming", Message: "a2", StartLine: 2, EndLine: 2, StartCol: 1, EndCol: 3, Type: annotation.Warning},
+			{FileName: "a.go", RuleID: "citk-unused-suppression", Message: "a1", StartLine: 2, EndLine: 2, StartCol: 1, EndCol: 3, Type: annotation.Notice},
+			// the same finding again, like the same misnamed variable in
+			// two functions
+			{FileName: "b.go", RuleID: "go-var-naming", Message: "b", StartLine: 5, EndLine: 5, StartCol: 1, EndCol: 2, Type: annotation.Warning},
+		}},
+		Base:    "base",
+		Head:    "head",
+		Version: "v1.0.0",
+	}
+	var buf bytes.Buffer
+	if err := JSON(&buf, run); err != nil {
+		t.Fatalf("failed to write JSON report: %s", err)
+	}
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode JSON report: %s", err)
+	}
+	var messages []string
+	for _, f := range report.Findings {
+		messages = append(messages, f.Message)
+	}
+	if expected := []string{"a1", "a2", "b", "b"}; !reflect.DeepEqual(expected, messages) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, messages)
+	}
+	expected := jsonFinding{
+		RuleID:      "go-var-naming",
+		Language:    "go",
+		Type:        "warning",
+		Message:     "b",
+		File:        "b.go",
+		StartLine:   1,
+		EndLine:     1,
+		StartColumn: 1,
+		EndColumn:   2,
+		Fingerprint: run.Result.Annotations[0].Fingerprint(),
+	}
+	if !reflect.DeepEqual(expected, report.Findings[2]) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, report.Findings[2])
+	}
+	if repeated := report.Findings[3].Fingerprint; repeated == expected.Fingerprint {
+		t.Errorf("expected repeated findings to have unique fingerprints, returned %s twice", repeated)
+	}
+	if report.Version != JSONSchemaVersion || report.Tool.Version != "v1.0.0" || report.Base != "base" || report.Head != "head" {
+		t.Errorf("expected the details of the run, returned %+v", report)
+	}
+
+	buf.Reset()
+	if err := JSONL(&buf, run); err != nil {
+		t.Fatalf("failed to write JSONL report: %s", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a line per finding, returned %q", lines)
+	}
+	var line jsonLine
+	if err := json.Unmarshal([]byte(lines[2]), &line); err != nil {
+		t.Fatalf("failed to decode JSONL report: %s", err)
+	}
+	if !reflect.DeepEqual(expected, line.Finding) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, line.Finding)
+	}
+}
+
+// TestJSONSchema checks that the published schema requires exactly the
+// fields the reports have.
+func TestJSONSchema(t *testing.T) {
+	b, err := os.ReadFile("../../schema/report.v1.schema.json")
+	if err != nil {
+		t.Fatalf("failed to read schema: %s", err)
+	}
+	type object struct {
+		Required []string `json:"required"`
+	}
+	var schema struct {
+		ID       string   `json:"$id"`
+		Required []string `json:"required"`
+		Defs     struct {
+			Version struct {
+				Const int `json:"const"`
+			} `json:"version"`
+			Finding object `json:"finding"`
+			Line    object `json:"line"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("failed to decode schema: %s", err)
+	}
+	if schema.ID != JSONSchemaURL {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", JSONSchemaURL, schema.ID)
+	}
+	if schema.Defs.Version.Const != JSONSchemaVersion {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", JSONSchemaVersion, schema.Defs.Version.Const)
+	}
+	for _, tc := range []struct {
+		value    any
+		required []string
+	}{
+		{value: jsonReport{}, required: schema.Required},
+		{value: jsonFinding{}, required: schema.Defs.Finding.Required},
+		{value: jsonLine{}, required: schema.Defs.Line.Required},
+	} {
+		expected := jsonKeys(t, tc.value)
+		returned := append([]string(nil), tc.required...)
+		sort.Strings(returned)
+		if !reflect.DeepEqual(expected, returned) {
+			t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+		}
+	}
+}
+
+func jsonKeys(t *testing.T, v any) []string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %s", v, err)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("failed to decode %T: %s", v, err)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
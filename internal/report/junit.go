@@ -0,0 +1,76 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit writes the annotations to w as a JUnit XML report with a
+// <testsuite> per file and a failed <testcase> per annotation. Without
+// annotations a single passing test case is written, as CI servers tend to
+// treat reports without any test as broken.
+func JUnit(w io.Writer, annotations []annotation.Annotation) error {
+	report := junitTestSuites{Name: "citk"}
+	for _, file := range groupByFile(annotations) {
+		suite := junitTestSuite{Name: file.name}
+		for _, a := range file.annotations {
+			position := fmt.Sprintf("%s:%d", file.name, a.StartLine)
+			if a.StartCol != 0 {
+				position += fmt.Sprintf(":%d", a.StartCol)
+			}
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      fmt.Sprintf("%s %s", a.RuleID, position),
+				ClassName: file.name,
+				Failure: &junitFailure{
+					Message: a.Message,
+					Type:    string(a.Type),
+					Text:    fmt.Sprintf("%s: %s: %s (%s)", position, a.Type, a.Message, a.RuleID),
+				},
+			})
+		}
+		suite.Tests = len(suite.Cases)
+		suite.Failures = len(suite.Cases)
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+	}
+	if len(report.Suites) == 0 {
+		report.Tests = 1
+		report.Suites = []junitTestSuite{{
+			Name:  "citk",
+			Tests: 1,
+			Cases: []junitTestCase{{Name: "citk", ClassName: "citk"}},
+		}}
+	}
+	return writeXML(w, report)
+}
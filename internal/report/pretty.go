@@ -0,0 +1,164 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// ANSI escape sequences used by Pretty.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiFaint  = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// Pretty writes the findings to w for people reading them in a terminal:
+// the position and message of each finding, followed by its source line,
+// read from dir, with the finding underlined, and a footer totalling the
+// findings per severity. color enables ANSI colors.
+func Pretty(w io.Writer, dir string, color bool, result inspect.Result) error {
+	p := prettyPrinter{color: color, src: newSources(dir)}
+	for _, a := range sortAnnotations(result.Annotations) {
+		p.finding(a)
+	}
+	p.footer(result)
+	_, err := io.WriteString(w, p.b.String())
+	return err
+}
+
+type prettyPrinter struct {
+	b     strings.Builder
+	color bool
+	src   *sources
+}
+
+// style wraps s in the ANSI sequence when colors are enabled.
+func (p *prettyPrinter) style(sequence, s string) string {
+	if !p.color || s == "" {
+		return s
+	}
+	return sequence + s + ansiReset
+}
+
+func (p *prettyPrinter) finding(a annotation.Annotation) {
+	if a.FileName != "" {
+		position := filepath.ToSlash(a.FileName)
+		if a.StartLine != 0 {
+			position += fmt.Sprintf(":%d", a.StartLine)
+			if a.StartCol != 0 {
+				position += fmt.Sprintf(":%d", a.StartCol)
+			}
+		}
+		p.b.WriteString(p.style(ansiBold, position) + ": ")
+	}
+	severity := severityColor(a.Type)
+	p.b.WriteString(p.style(ansiBold+severity, string(a.Type)) + ": " + a.Message)
+	if a.RuleID != "" {
+		p.b.WriteString(" " + p.style(ansiFaint, "("+a.RuleID+")"))
+	}
+	p.b.WriteString("\n")
+
+	line, ok := p.src.line(a.FileName, a.StartLine)
+	if !ok {
+		p.b.WriteString("\n")
+		return
+	}
+	gutter := fmt.Sprintf("%d", a.StartLine)
+	fmt.Fprintf(&p.b, " %s %s %s\n", p.style(ansiFaint, gutter), p.style(ansiFaint, "|"), line)
+	if marker := underline(line, a); marker != "" {
+		fmt.Fprintf(&p.b, " %s %s %s\n", strings.Repeat(" ", len(gutter)), p.style(ansiFaint, "|"), p.style(severity, marker))
+	}
+	p.b.WriteString("\n")
+}
+
+// underline returns the line drawn under the first line of a: a caret under
+// its first character and tildes under the rest of it, up to the end of the
+// line for findings spanning several lines. Tabs before the finding are
+// kept, so the caret lines up however wide the terminal draws them.
+func underline(line string, a annotation.Annotation) string {
+	if a.StartCol == 0 {
+		return ""
+	}
+	width := utf8.RuneCountInString(line)
+	end := int(a.EndCol)
+	if a.EndLine > a.StartLine || end == 0 {
+		end = width + 1
+	}
+	start := int(a.StartCol)
+	if end <= start {
+		end = start + 1
+	}
+	var b strings.Builder
+	col := 1
+	for _, r := range line {
+		if col == start {
+			break
+		}
+		if r == '\t' {
+			b.WriteRune('\t')
+		} else {
+			b.WriteRune(' ')
+		}
+		col++
+	}
+	// the finding can start past the end of the line, like at its line ending
+	b.WriteString(strings.Repeat(" ", start-col))
+	b.WriteString("^" + strings.Repeat("~", end-start-1))
+	return b.String()
+}
+
+func severityColor(t annotation.AnnotationType) string {
+	switch t {
+	case annotation.Error:
+		return ansiRed
+	case annotation.Warning:
+		return ansiYellow
+	case annotation.Notice:
+		return ansiCyan
+	default:
+		return ansiFaint
+	}
+}
+
+func (p *prettyPrinter) footer(result inspect.Result) {
+	if len(result.Annotations) == 0 {
+		p.b.WriteString(p.style(ansiBold, "No findings."))
+	} else {
+		perSeverity := map[annotation.AnnotationType]int{}
+		for _, a := range result.Annotations {
+			perSeverity[a.Type]++
+		}
+		var totals []string
+		for _, severity := range severities {
+			if n := perSeverity[severity]; n > 0 {
+				noun := string(severity)
+				if severity == annotation.Debug {
+					noun = "debug message"
+				}
+				totals = append(totals, p.style(severityColor(severity), plural(n, noun)))
+			}
+		}
+		p.b.WriteString(p.style(ansiBold, plural(len(result.Annotations), "finding")+":") + " " + strings.Join(totals, ", "))
+	}
+	if n := len(result.Suppressed); n > 0 {
+		fmt.Fprintf(&p.b, " (%d suppressed)", n)
+	}
+	p.b.WriteString("\n")
+}
+
+// plural returns n followed by word, in the plural unless n is 1.
+func plural(n int, word string) string {
+	if n == 1 {
+		return "1 " + word
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
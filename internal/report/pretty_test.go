@@ -0,0 +1,47 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+func TestPretty(t *testing.T) {
+	result := inspect.Result{
+		Annotations: []annotation.Annotation{
+			{FileName: "pretty.go", RuleID: "go-var-naming", Message: "bad_var is bad", StartLine: 4, EndLine: 4, StartCol: 2, EndCol: 9, Type: annotation.Warning},
+			{FileName: "pretty.go", RuleID: "go-func-naming", Message: "spans lines", StartLine: 3, EndLine: 6, StartCol: 1, EndCol: 2, Type: annotation.Error},
+			{FileName: "missing.go", RuleID: "go-var-naming", Message: "no source", StartLine: 1, Type: annotation.Warning},
+		},
+		Suppressed: []inspect.Suppressed{{}},
+	}
+	var buf bytes.Buffer
+	if err := Pretty(&buf, "testdata", false, result); err != nil {
+		t.Fatalf("failed to write pretty output: %s", err)
+	}
+	expected := `missing.go:1: warning: no source (go-var-naming)
+
+pretty.go:3:1: error: spans lines (go-func-naming)
+ 3 | func main() {
+   | ^~~~~~~~~~~~~
+
+pretty.go:4:2: warning: bad_var is bad (go-var-naming)
+ 4 | 	bad_var := 1
+   | 	^~~~~~~
+
+3 findings: 1 error, 2 warnings (1 suppressed)
+`
+	if buf.String() != expected {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := Pretty(&buf, "testdata", true, inspect.Result{}); err != nil {
+		t.Fatalf("failed to write pretty output: %s", err)
+	}
+	if expected := ansiBold + "No findings." + ansiReset + "\n"; buf.String() != expected {
+		t.Errorf("expected and returned values do not match: expected %q, returned %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+// rdjsonResult is a reviewdog DiagnosticResult.
+type rdjsonResult struct {
+	Source      rdjsonSource       `json:"source"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type rdjsonDiagnostic struct {
+	Message  string         `json:"message"`
+	Location rdjsonLocation `json:"location"`
+	Severity string         `json:"severity"`
+	// Source is only set in rdjsonl, where every line stands on its own.
+	Source *rdjsonSource `json:"source,omitempty"`
+	Code   *rdjsonCode   `json:"code,omitempty"`
+}
+
+type rdjsonLocation struct {
+	Path  string       `json:"path"`
+	Range *rdjsonRange `json:"range,omitempty"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+type rdjsonPosition struct {
+	Line   uint32 `json:"line"`
+	Column uint32 `json:"column,omitempty"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+}
+
+var citkSource = rdjsonSource{Name: "citk", URL: "https://github.com/tjgurwara99/citk"}
+
+// RDJSON writes the annotations to w as a reviewdog DiagnosticResult, for
+// reviewdog -f=rdjson. Files are read from dir to convert columns to the
+// UTF-8 byte offsets reviewdog expects.
+func RDJSON(w io.Writer, dir string, annotations []annotation.Annotation) error {
+	result := rdjsonResult{Source: citkSource, Diagnostics: []rdjsonDiagnostic{}}
+	src := newSources(dir)
+	for _, a := range annotations {
+		result.Diagnostics = append(result.Diagnostics, rdjsonFromAnnotation(src, a))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// RDJSONL writes the annotations to w as reviewdog Diagnostics, one per
+// line, for reviewdog -f=rdjsonl.
+func RDJSONL(w io.Writer, dir string, annotations []annotation.Annotation) error {
+	enc := json.NewEncoder(w)
+	src := newSources(dir)
+	for _, a := range annotations {
+		d := rdjsonFromAnnotation(src, a)
+		d.Source = &citkSource
+		if err := enc.Encode(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rdjsonFromAnnotation(src *sources, a annotation.Annotation) rdjsonDiagnostic {
+	d := rdjsonDiagnostic{
+		Message:  a.Message,
+		Location: rdjsonLocation{Path: filepath.ToSlash(a.FileName)},
+		Severity: rdjsonSeverity(a.Type),
+	}
+	if a.RuleID != "" {
+		d.Code = &rdjsonCode{Value: a.RuleID}
+	}
+	if a.StartLine == 0 {
+		return d
+	}
+	d.Location.Range = &rdjsonRange{Start: rdjsonPosition{Line: a.StartLine, Column: rdjsonColumn(src, a.FileName, a.StartLine, a.StartCol)}}
+	if a.EndLine != 0 {
+		d.Location.Range.End = &rdjsonPosition{Line: a.EndLine, Column: rdjsonColumn(src, a.FileName, a.EndLine, a.EndCol)}
+	}
+	return d
+}
+
+// rdjsonColumn converts a code point column to a byte column, leaving it as
+// it is when the line can't be read.
+func rdjsonColumn(src *sources, fileName string, line, col uint32) uint32 {
+	if text, ok := src.line(fileName, line); ok {
+		return byteColumn(text, col)
+	}
+	return col
+}
+
+// rdjsonSeverity maps an AnnotationType to a reviewdog severity.
+func rdjsonSeverity(t annotation.AnnotationType) string {
+	switch t {
+	case annotation.Error:
+		return "ERROR"
+	case annotation.Warning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+func TestRDJSON(t *testing.T) {
+	annotations := []annotation.Annotation{
+		{
+			FileName:  "unicode.go",
+			RuleID:    "go-var-naming",
+			Message:   "héllo_wörld is bad",
+			StartLine: 3,
+			EndLine:   3,
+			StartCol:  5,
+			EndCol:    16,
+			Type:      annotation.Warning,
+		},
+		{FileName: "missing.go", Message: "no range", Type: annotation.Notice},
+	}
+	var buf bytes.Buffer
+	if err := RDJSON(&buf, "testdata", annotations); err != nil {
+		t.Fatalf("failed to write rdjson: %s", err)
+	}
+	var result rdjsonResult
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("failed to decode rdjson: %s", err)
+	}
+	expected := rdjsonResult{
+		Source: citkSource,
+		Diagnostics: []rdjsonDiagnostic{
+			{
+				Message: "héllo_wörld is bad",
+				Location: rdjsonLocation{
+					Path: "unicode.go",
+					// the columns count the two byte é and ö twice
+					Range: &rdjsonRange{Start: rdjsonPosition{Line: 3, Column: 5}, End: &rdjsonPosition{Line: 3, Column: 18}},
+				},
+				Severity: "WARNING",
+				Code:     &rdjsonCode{Value: "go-var-naming"},
+			},
+			{Message: "no range", Location: rdjsonLocation{Path: "missing.go"}, Severity: "INFO"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, result)
+	}
+
+	buf.Reset()
+	if err := RDJSONL(&buf, "testdata", annotations); err != nil {
+		t.Fatalf("failed to write rdjsonl: %s", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(annotations) {
+		t.Fatalf("expected a line per annotation, returned %q", lines)
+	}
+	var d rdjsonDiagnostic
+	if err := json.Unmarshal([]byte(lines[0]), &d); err != nil {
+		t.Fatalf("failed to decode rdjsonl: %s", err)
+	}
+	expectedLine := expected.Diagnostics[0]
+	expectedLine.Source = &citkSource
+	if !reflect.DeepEqual(expectedLine, d) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expectedLine, d)
+	}
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// Run is the outcome of a check that is handed to reporters.
+type Run struct {
+	// Rules are the rules the check ran.
+	Rules  []inspect.Rule
+	Result inspect.Result
+	// Dir is the directory the file names of the annotations are relative
+	// to.
+	Dir string
+	// Base is the merge base the changes were taken from, empty when every
+	// file was inspected.
+	Base string
+	// Head is the commit that was inspected.
+	Head string
+	// Version is the version of citk.
+	Version string
+	// Color enables ANSI colors in output meant for people.
+	Color bool
+	// GitHubAPIURL is the base URL of the GitHub REST API the GitHub
+	// reporters use, empty for the default.
+	GitHubAPIURL string
+}
+
+// Reporter publishes the findings of a run, either by writing them to w in
+// a format a CI system or tool understands or by sending them to a service
+// and describing what it did on w.
+type Reporter interface {
+	Report(w io.Writer, run Run) error
+}
+
+// ReporterFunc adapts a function to the Reporter interface.
+type ReporterFunc func(w io.Writer, run Run) error
+
+// Report calls f(w, run).
+func (f ReporterFunc) Report(w io.Writer, run Run) error {
+	return f(w, run)
+}
+
+// reporters holds the reporters by name.
+var reporters = map[string]Reporter{}
+
+// RegisterReporter makes reporter available under name. It panics when the
+// name is taken, as reporters are registered from init functions.
+func RegisterReporter(name string, reporter Reporter) {
+	if _, ok := reporters[name]; ok {
+		panic(fmt.Sprintf("reporter %s is already registered", name))
+	}
+	reporters[name] = reporter
+}
+
+// LookupReporter returns the reporter registered under name.
+func LookupReporter(name string) (Reporter, bool) {
+	reporter, ok := reporters[name]
+	return reporter, ok
+}
+
+// ReporterNames returns the names of the registered reporters in
+// alphabetical order.
+func ReporterNames() []string {
+	names := make([]string, 0, len(reporters))
+	for name := range reporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DetectReporter returns the name of the reporter whose output the CI
+// system running citk understands, judging by the environment variables
+// getenv reads. Outside of a known CI system it returns "pretty" when the
+// output goes to a terminal and "github" otherwise.
+func DetectReporter(getenv func(string) string, terminal bool) string {
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		return "github"
+	case getenv("TF_BUILD") != "":
+		return "azure"
+	case getenv("TEAMCITY_VERSION") != "":
+		return "teamcity"
+	case getenv("BUILDKITE") == "true":
+		return "buildkite"
+	case terminal:
+		return "pretty"
+	default:
+		return "github"
+	}
+}
+
+func init() {
+	RegisterReporter("github", ReporterFunc(func(w io.Writer, run Run) error {
+		for _, a := range run.Result.Annotations {
+			if _, err := fmt.Fprintln(w, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	RegisterReporter("sarif", ReporterFunc(func(w io.Writer, run Run) error {
+		return SARIF(w, run.Rules, run.Result.Annotations)
+	}))
+	RegisterReporter("gitlab", ReporterFunc(func(w io.Writer, run Run) error {
+		return GitLab(w, run.Result.Annotations)
+	}))
+	RegisterReporter("checkstyle", ReporterFunc(func(w io.Writer, run Run) error {
+		return Checkstyle(w, run.Result.Annotations)
+	}))
+	RegisterReporter("junit", ReporterFunc(func(w io.Writer, run Run) error {
+		return JUnit(w, run.Result.Annotations)
+	}))
+	RegisterReporter("pretty", ReporterFunc(func(w io.Writer, run Run) error {
+		return Pretty(w, run.Dir, run.Color, run.Result)
+	}))
+	RegisterReporter("json", ReporterFunc(JSON))
+	RegisterReporter("jsonl", ReporterFunc(JSONL))
+	RegisterReporter("rdjson", ReporterFunc(func(w io.Writer, run Run) error {
+		return RDJSON(w, run.Dir, run.Result.Annotations)
+	}))
+	RegisterReporter("rdjsonl", ReporterFunc(func(w io.Writer, run Run) error {
+		return RDJSONL(w, run.Dir, run.Result.Annotations)
+	}))
+	RegisterReporter("azure", ReporterFunc(Azure))
+	RegisterReporter("teamcity", ReporterFunc(TeamCity))
+	RegisterReporter("buildkite", ReporterFunc(Buildkite))
+	RegisterReporter("github-pr-review", ReporterFunc(GitHubPullRequestReview))
+	RegisterReporter("github-check", ReporterFunc(GitHubCheckRun))
+}
@@ -0,0 +1,160 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 sarifMessage       `json:"help"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   uint32 `json:"startLine,omitempty"`
+	StartColumn uint32 `json:"startColumn,omitempty"`
+	EndLine     uint32 `json:"endLine,omitempty"`
+	EndColumn   uint32 `json:"endColumn,omitempty"`
+}
+
+// sarifLevel maps an AnnotationType to the equivalent SARIF result level.
+func sarifLevel(t annotation.AnnotationType) string {
+	switch t {
+	case annotation.Error:
+		return "error"
+	case annotation.Warning:
+		return "warning"
+	case annotation.Notice:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// SARIF writes the annotations to w as a SARIF 2.1.0 log with a single run.
+// Every rule becomes a reportingDescriptor on the citk driver, whether or not
+// it produced any results.
+func SARIF(w io.Writer, rules []inspect.Rule, annotations []annotation.Annotation) error {
+	driver := sarifDriver{
+		Name:           "citk",
+		InformationURI: "https://github.com/tjgurwara99/citk",
+		Rules:          []sarifReportingDescriptor{},
+	}
+	ruleIndex := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		ruleIndex[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifReportingDescriptor{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Title},
+			Help:                 sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, a := range annotations {
+		result := sarifResult{
+			RuleID:  a.RuleID,
+			Level:   sarifLevel(a.Type),
+			Message: sarifMessage{Text: a.Message},
+		}
+		if i, ok := ruleIndex[a.RuleID]; ok {
+			i := i
+			result.RuleIndex = &i
+		}
+		if a.FileName != "" {
+			loc := sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI:       filepath.ToSlash(a.FileName),
+					URIBaseID: "%SRCROOT%",
+				},
+			}
+			if a.StartLine != 0 {
+				loc.Region = &sarifRegion{
+					StartLine:   a.StartLine,
+					StartColumn: a.StartCol,
+					EndLine:     a.EndLine,
+					EndColumn:   a.EndCol,
+				}
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:       sarifTool{Driver: driver},
+			ColumnKind: "unicodeCodePoints",
+			Results:    results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,64 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+func TestSARIF(t *testing.T) {
+	rules := []inspect.Rule{
+		{ID: "go-func-naming", Title: "Bad func", Description: "Use mixedCaps.", Severity: annotation.Error},
+		{ID: "go-var-naming", Title: "Bad var", Description: "Use mixedCaps.", Severity: annotation.Warning},
+	}
+	annotations := []annotation.Annotation{
+		{
+			FileName:  "pkg/a.go",
+			RuleID:    "go-var-naming",
+			Title:     "Bad var",
+			Message:   "bad_var is bad",
+			StartLine: 3,
+			EndLine:   3,
+			StartCol:  5,
+			EndCol:    12,
+			Type:      annotation.Notice,
+		},
+	}
+	var buf bytes.Buffer
+	if err := SARIF(&buf, rules, annotations); err != nil {
+		t.Fatalf("failed to write SARIF: %s", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("failed to decode SARIF output: %s", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].ColumnKind != "unicodeCodePoints" {
+		t.Fatalf("unexpected log header: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[1].DefaultConfiguration.Level != "warning" {
+		t.Errorf("unexpected rules: %+v", run.Tool.Driver.Rules)
+	}
+	index := 1
+	expected := []sarifResult{
+		{
+			RuleID:    "go-var-naming",
+			RuleIndex: &index,
+			Level:     "note",
+			Message:   sarifMessage{Text: "bad_var is bad"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: "pkg/a.go", URIBaseID: "%SRCROOT%"},
+					Region:           &sarifRegion{StartLine: 3, StartColumn: 5, EndLine: 3, EndColumn: 12},
+				},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(expected, run.Results) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, run.Results)
+	}
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// sources reads the lines of the files annotations point at, reading every
+// file at most once.
+type sources struct {
+	dir   string
+	files map[string][]string
+}
+
+// newSources returns sources reading files relative to dir.
+func newSources(dir string) *sources {
+	return &sources{dir: dir, files: map[string][]string{}}
+}
+
+// line returns the 1-based line n of fileName without its line ending. It
+// reports false when the file can't be read or is shorter.
+func (s *sources) line(fileName string, n uint32) (string, bool) {
+	lines, ok := s.files[fileName]
+	if !ok {
+		src, err := os.ReadFile(filepath.Join(s.dir, fileName))
+		if err == nil {
+			lines = strings.Split(string(src), "\n")
+		}
+		s.files[fileName] = lines
+	}
+	if n == 0 || int(n) > len(lines) {
+		return "", false
+	}
+	return strings.TrimSuffix(lines[n-1], "\r"), true
+}
+
+// byteColumn converts the 1-based code point column col of line to a
+// 1-based UTF-8 byte column. Columns past the end of the line keep counting
+// one byte per column.
+func byteColumn(line string, col uint32) uint32 {
+	if col == 0 {
+		return 0
+	}
+	offset := 0
+	for i := uint32(1); i < col; i++ {
+		if offset >= len(line) {
+			offset++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(line[offset:])
+		offset += size
+	}
+	return uint32(offset) + 1
+}
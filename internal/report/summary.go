@@ -0,0 +1,137 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// severities lists the annotation types from the most to the least severe.
+var severities = []annotation.AnnotationType{annotation.Error, annotation.Warning, annotation.Notice, annotation.Debug}
+
+// Summary writes a Markdown report of the findings to w, meant for the
+// GitHub Actions job summary. It totals the findings per severity and rule,
+// lists them grouped by file and collapses the suppressed findings.
+//
+// blobURL is the URL files are linked to, like
+// https://github.com/owner/repo/blob/<sha>. Lines aren't linked when it is
+// empty.
+func Summary(w io.Writer, blobURL string, result inspect.Result) error {
+	var b strings.Builder
+	b.WriteString("## citk\n\n")
+	if len(result.Annotations) == 0 {
+		b.WriteString("No findings.\n")
+	} else {
+		writeTotals(&b, result.Annotations)
+		writeFindings(&b, blobURL, result.Annotations)
+	}
+	if len(result.Suppressed) > 0 {
+		writeSuppressed(&b, blobURL, result.Suppressed)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeTotals(b *strings.Builder, annotations []annotation.Annotation) {
+	perSeverity := map[annotation.AnnotationType]int{}
+	perRule := map[string]int{}
+	ruleSeverity := map[string]annotation.AnnotationType{}
+	for _, a := range annotations {
+		perSeverity[a.Type]++
+		perRule[a.RuleID]++
+		ruleSeverity[a.RuleID] = a.Type
+	}
+
+	b.WriteString("| Severity | Findings |\n| --- | ---: |\n")
+	for _, severity := range severities {
+		if n := perSeverity[severity]; n > 0 {
+			fmt.Fprintf(b, "| %s | %d |\n", severity, n)
+		}
+	}
+	b.WriteString("\n")
+
+	rules := make([]string, 0, len(perRule))
+	for rule := range perRule {
+		rules = append(rules, rule)
+	}
+	// the most frequent rules come first
+	sort.Slice(rules, func(i, j int) bool {
+		if perRule[rules[i]] != perRule[rules[j]] {
+			return perRule[rules[i]] > perRule[rules[j]]
+		}
+		return rules[i] < rules[j]
+	})
+	b.WriteString("| Rule | Severity | Findings |\n| --- | --- | ---: |\n")
+	for _, rule := range rules {
+		fmt.Fprintf(b, "| %s | %s | %d |\n", code(rule), ruleSeverity[rule], perRule[rule])
+	}
+	b.WriteString("\n")
+}
+
+func writeFindings(b *strings.Builder, blobURL string, annotations []annotation.Annotation) {
+	byFile := map[string][]annotation.Annotation{}
+	var files []string
+	for _, a := range annotations {
+		if _, ok := byFile[a.FileName]; !ok {
+			files = append(files, a.FileName)
+		}
+		byFile[a.FileName] = append(byFile[a.FileName], a)
+	}
+	sort.Strings(files)
+
+	b.WriteString("### Findings\n")
+	for _, file := range files {
+		findings := byFile[file]
+		sort.SliceStable(findings, func(i, j int) bool {
+			return findings[i].StartLine < findings[j].StartLine
+		})
+		fmt.Fprintf(b, "\n#### %s\n\n", code(filepath.ToSlash(file)))
+		b.WriteString("| Line | Severity | Rule | Message |\n| ---: | --- | --- | --- |\n")
+		for _, a := range findings {
+			fmt.Fprintf(b, "| %s | %s | %s | %s |\n", lineLink(blobURL, a), a.Type, code(a.RuleID), cell(a.Message))
+		}
+	}
+}
+
+func writeSuppressed(b *strings.Builder, blobURL string, suppressed []inspect.Suppressed) {
+	fmt.Fprintf(b, "\n<details>\n<summary>%d suppressed finding(s)</summary>\n\n", len(suppressed))
+	b.WriteString("| File | Line | Rule | Reason |\n| --- | ---: | --- | --- |\n")
+	for _, s := range suppressed {
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n", code(filepath.ToSlash(s.FileName)), lineLink(blobURL, s.Annotation), code(s.RuleID), cell(s.Reason))
+	}
+	b.WriteString("\n</details>\n")
+}
+
+// lineLink links the lines of a to their source, or just names them
+// without a blobURL.
+func lineLink(blobURL string, a annotation.Annotation) string {
+	line := fmt.Sprint(a.StartLine)
+	if blobURL == "" || a.FileName == "" || a.StartLine == 0 {
+		return line
+	}
+	anchor := fmt.Sprintf("L%d", a.StartLine)
+	if a.EndLine > a.StartLine {
+		anchor += fmt.Sprintf("-L%d", a.EndLine)
+	}
+	return fmt.Sprintf("[%s](%s/%s#%s)", line, strings.TrimSuffix(blobURL, "/"), filepath.ToSlash(a.FileName), anchor)
+}
+
+// cell escapes s for use in a Markdown table cell.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// code formats s as inline code in a table cell.
+func code(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(s, "|", "\\|") + "`"
+}
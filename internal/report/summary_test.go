@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+func TestSummary(t *testing.T) {
+	result := inspect.Result{
+		Annotations: []annotation.Annotation{
+			{FileName: "pkg/b.go", RuleID: "go-var-naming", Message: "bad_var | is bad", StartLine: 9, EndLine: 9, Type: annotation.Warning},
+			{FileName: "pkg/a.go", RuleID: "go-func-naming", Message: "bad_func is bad", StartLine: 7, EndLine: 8, Type: annotation.Error},
+			{FileName: "pkg/a.go", RuleID: "go-func-naming", Message: "other_func is bad", StartLine: 3, EndLine: 3, Type: annotation.Error},
+		},
+		Suppressed: []inspect.Suppressed{{
+			Annotation: annotation.Annotation{FileName: "pkg/a.go", RuleID: "go-const-naming", StartLine: 1, EndLine: 1, Type: annotation.Error},
+			Reason:     "mirrors\nthe C header",
+		}},
+	}
+	var buf bytes.Buffer
+	if err := Summary(&buf, "https://github.com/o/r/blob/abc/", result); err != nil {
+		t.Fatalf("failed to write summary: %s", err)
+	}
+	expected := "## citk\n\n" +
+		"| Severity | Findings |\n| --- | ---: |\n| error | 2 |\n| warning | 1 |\n\n" +
+		"| Rule | Severity | Findings |\n| --- | --- | ---: |\n| `go-func-naming` | error | 2 |\n| `go-var-naming` | warning | 1 |\n\n" +
+		"### Findings\n" +
+		"\n#### `pkg/a.go`\n\n| Line | Severity | Rule | Message |\n| ---: | --- | --- | --- |\n" +
+		"| [3](https://github.com/o/r/blob/abc/pkg/a.go#L3) | error | `go-func-naming` | other_func is bad |\n" +
+		"| [7](https://github.com/o/r/blob/abc/pkg/a.go#L7-L8) | error | `go-func-naming` | bad_func is bad |\n" +
+		"\n#### `pkg/b.go`\n\n| Line | Severity | Rule | Message |\n| ---: | --- | --- | --- |\n" +
+		"| [9](https://github.com/o/r/blob/abc/pkg/b.go#L9) | warning | `go-var-naming` | bad_var \\| is bad |\n" +
+		"\n<details>\n<summary>1 suppressed finding(s)</summary>\n\n| File | Line | Rule | Reason |\n| --- | ---: | --- | --- |\n" +
+		"| `pkg/a.go` | [1](https://github.com/o/r/blob/abc/pkg/a.go#L1) | `go-const-naming` | mirrors<br>the C header |\n" +
+		"\n</details>\n"
+	if returned := buf.String(); expected != returned {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+}
+
+func TestSummaryWithoutFindings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Summary(&buf, "", inspect.Result{}); err != nil {
+		t.Fatalf("failed to write summary: %s", err)
+	}
+	if expected, returned := "## citk\n\nNo findings.\n", buf.String(); expected != returned {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+}
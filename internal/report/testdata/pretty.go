@@ -0,0 +1,6 @@
+package main
+
+func main() {
+	bad_var := 1
+	_ = bad_var
+}
@@ -0,0 +1,3 @@
+package main
+
+var héllo_wörld = 1
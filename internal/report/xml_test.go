@@ -0,0 +1,77 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/annotation"
+)
+
+var xmlAnnotations = []annotation.Annotation{
+	{FileName: "pkg/b.go", RuleID: "go-var-naming", Message: "bad_var <is> bad", StartLine: 9, StartCol: 5, Type: annotation.Warning},
+	{FileName: "pkg/a.go", RuleID: "go-func-naming", Message: "bad_func is bad", StartLine: 7, StartCol: 6, Type: annotation.Error},
+	{FileName: "pkg/a.go", RuleID: "citk-unused-suppression", Message: "unused", StartLine: 3, Type: annotation.Notice},
+}
+
+func TestCheckstyle(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Checkstyle(&buf, xmlAnnotations); err != nil {
+		t.Fatalf("failed to write Checkstyle report: %s", err)
+	}
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="pkg/a.go">
+    <error line="3" severity="info" message="unused" source="citk-unused-suppression"></error>
+    <error line="7" column="6" severity="error" message="bad_func is bad" source="go-func-naming"></error>
+  </file>
+  <file name="pkg/b.go">
+    <error line="9" column="5" severity="warning" message="bad_var &lt;is&gt; bad" source="go-var-naming"></error>
+  </file>
+</checkstyle>
+`
+	if returned := buf.String(); expected != returned {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := JUnit(&buf, xmlAnnotations); err != nil {
+		t.Fatalf("failed to write JUnit report: %s", err)
+	}
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="citk" tests="3" failures="3">
+  <testsuite name="pkg/a.go" tests="2" failures="2">
+    <testcase name="citk-unused-suppression pkg/a.go:3" classname="pkg/a.go">
+      <failure message="unused" type="notice">pkg/a.go:3: notice: unused (citk-unused-suppression)</failure>
+    </testcase>
+    <testcase name="go-func-naming pkg/a.go:7:6" classname="pkg/a.go">
+      <failure message="bad_func is bad" type="error">pkg/a.go:7:6: error: bad_func is bad (go-func-naming)</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="pkg/b.go" tests="1" failures="1">
+    <testcase name="go-var-naming pkg/b.go:9:5" classname="pkg/b.go">
+      <failure message="bad_var &lt;is&gt; bad" type="warning">pkg/b.go:9:5: warning: bad_var &lt;is&gt; bad (go-var-naming)</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if returned := buf.String(); expected != returned {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+
+	buf.Reset()
+	if err := JUnit(&buf, nil); err != nil {
+		t.Fatalf("failed to write JUnit report: %s", err)
+	}
+	expected = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="citk" tests="1" failures="0">
+  <testsuite name="citk" tests="1" failures="0">
+    <testcase name="citk" classname="citk"></testcase>
+  </testsuite>
+</testsuites>
+`
+	if returned := buf.String(); expected != returned {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, returned)
+	}
+}
@@ -0,0 +1,218 @@
+package rust
+
+import (
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// Ident is an identifier found by one of the Rust inspectors.
+type Ident = inspect.Ident
+
+// Language describes Rust source files to the inspection engine.
+var Language = inspect.Language{
+	Name:       "rust",
+	Extensions: []string{".rs"},
+	Grammar:    rust.GetLanguage(),
+}
+
+var (
+	// Leading underscores mark unused items and are allowed by every style.
+	// A trailing underscore avoids a keyword, like in type_.
+	snakeCaseRE      = regexp.MustCompile(`^_*[a-z][a-z0-9]*(_[a-z0-9]+)*_?$`)
+	upperCamelCaseRE = regexp.MustCompile(`^_*[A-Z][a-zA-Z0-9]*$`)
+	screamingSnakeRE = regexp.MustCompile(`^_*[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// name strips the r# prefix of raw identifiers.
+func name(ident string) string {
+	return strings.TrimPrefix(ident, "r#")
+}
+
+func isSnakeCase(ident string) bool {
+	ident = name(ident)
+	return strings.Trim(ident, "_") == "" || snakeCaseRE.MatchString(ident)
+}
+
+func isUpperCamelCase(ident string) bool {
+	return upperCamelCaseRE.MatchString(name(ident))
+}
+
+func isScreamingSnakeCase(ident string) bool {
+	return screamingSnakeRE.MatchString(name(ident))
+}
+
+func anomalousFunctions(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterFunctions := `[
+		(function_item name: (identifier) @func)
+		(function_signature_item name: (identifier) @func)
+	]`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isSnakeCase(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterFunctions, "", check)
+}
+
+func anomalousModules(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterModules := `(
+		(mod_item name: (identifier) @module)
+	)`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isSnakeCase(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterModules, "", check)
+}
+
+func anomalousVariables(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	// Only plain bindings are checked, identifiers in other patterns may
+	// be enum variants or constants being matched against.
+	filterVariables := `[
+		(let_declaration pattern: (identifier) @var)
+		(let_declaration pattern: (tuple_pattern (identifier) @var))
+		(parameter pattern: (identifier) @var)
+		(for_expression pattern: (identifier) @var)
+	]`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isSnakeCase(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterVariables, "", check)
+}
+
+func anomalousTypes(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterTypes := `[
+		(struct_item name: (type_identifier) @type)
+		(enum_item name: (type_identifier) @type)
+		(union_item name: (type_identifier) @type)
+		(type_item name: (type_identifier) @type)
+		(trait_item name: (type_identifier) @type)
+	]`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isUpperCamelCase(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterTypes, "", check)
+}
+
+func anomalousVariants(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterVariants := `(
+		(enum_variant name: (identifier) @variant)
+	)`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isUpperCamelCase(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterVariants, "", check)
+}
+
+func anomalousConsts(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterConsts := `[
+		(const_item name: (identifier) @constant)
+		(static_item name: (identifier) @constant)
+	]`
+	check := inspect.WithExceptions(func(ident string) bool {
+		// const _ only evaluates its value and has no name
+		return ident != "_" && !isScreamingSnakeCase(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterConsts, "", check)
+}
+
+// Rules lists the naming conventions of RFC 430 checked in Rust source
+// files.
+var Rules = []inspect.Rule{
+	{
+		ID:          "rust-const-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Constant name not in SCREAMING_SNAKE_CASE",
+		Description: "Constants and statics are written in SCREAMING_SNAKE_CASE.",
+		Examples: []inspect.Example{{
+			Bad:  "const max_size: usize = 64;",
+			Good: "const MAX_SIZE: usize = 64;",
+		}},
+		Message:  "The declaration of the constant %s is not in SCREAMING_SNAKE_CASE.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousConsts,
+	},
+	{
+		ID:          "rust-function-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Function name not in snake_case",
+		Description: "Functions and methods are written in snake_case.",
+		Examples: []inspect.Example{{
+			Bad:  "fn parseConfig(path: &Path) {}",
+			Good: "fn parse_config(path: &Path) {}",
+		}},
+		Message:  "The declaration of the function %s is not in snake_case.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousFunctions,
+	},
+	{
+		ID:          "rust-module-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Module name not in snake_case",
+		Description: "Modules are written in snake_case.",
+		Examples: []inspect.Example{{
+			Bad:  "mod HttpClient;",
+			Good: "mod http_client;",
+		}},
+		Message:  "The declaration of the module %s is not in snake_case.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousModules,
+	},
+	{
+		ID:          "rust-type-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Type name not in UpperCamelCase",
+		Description: "Structs, enums, unions, type aliases and traits are written in UpperCamelCase.",
+		Examples: []inspect.Example{{
+			Bad:  "struct http_client {}",
+			Good: "struct HttpClient {}",
+		}},
+		Message:  "The declaration of the type %s is not in UpperCamelCase.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousTypes,
+	},
+	{
+		ID:          "rust-variable-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Variable name not in snake_case",
+		Description: "Local variables and function parameters are written in snake_case.",
+		Examples: []inspect.Example{{
+			Bad:  "let retryCount = 0;",
+			Good: "let retry_count = 0;",
+		}},
+		Message:  "The variable %s is not in snake_case.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousVariables,
+	},
+	{
+		ID:          "rust-variant-naming",
+		Language:    Language.Name,
+		Category:    "naming",
+		Title:       "Enum variant name not in UpperCamelCase",
+		Description: "Enum variants are written in UpperCamelCase.",
+		Examples: []inspect.Example{{
+			Bad:  "enum Color {\n    DARK_RED,\n}",
+			Good: "enum Color {\n    DarkRed,\n}",
+		}},
+		Message:  "The declaration of the enum variant %s is not in UpperCamelCase.",
+		Severity: annotation.Error,
+		Options:  []inspect.Option{inspect.ExceptionsOption},
+		Inspect:  anomalousVariants,
+	},
+}
+
+func init() {
+	inspect.MustRegister(Rules...)
+}
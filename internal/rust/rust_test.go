@@ -0,0 +1,112 @@
+package rust
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAnomalousFunctionsAndModules(t *testing.T) {
+	file, err := os.ReadFile("./testdata/functions.rs")
+	if err != nil {
+		t.Fatalf("failed to open testdata/functions.rs: %s", err.Error())
+	}
+	funcs, err := anomalousFunctions(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "parseArgs", Line: 8, EndLine: 8, Col: 4, EndCol: 13},
+		{Name: "Run", Line: 11, EndLine: 11, Col: 8, EndCol: 11},
+		{Name: "Run", Line: 15, EndLine: 15, Col: 8, EndCol: 11},
+	}
+
+	if !reflect.DeepEqual(expected, funcs) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, funcs)
+	}
+
+	modules, err := anomalousModules(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected = []Ident{
+		{Name: "HttpClient", Line: 2, EndLine: 2, Col: 5, EndCol: 15},
+		{Name: "inner_Mod", Line: 4, EndLine: 4, Col: 9, EndCol: 18},
+	}
+
+	if !reflect.DeepEqual(expected, modules) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, modules)
+	}
+}
+
+func TestAnomalousVariables(t *testing.T) {
+	file, err := os.ReadFile("./testdata/variables.rs")
+	if err != nil {
+		t.Fatalf("failed to open testdata/variables.rs: %s", err.Error())
+	}
+	vars, err := anomalousVariables(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "retryCount", Line: 1, EndLine: 1, Col: 9, EndCol: 19},
+		{Name: "runningTotal", Line: 3, EndLine: 3, Col: 13, EndCol: 25},
+		{Name: "secondItem", Line: 4, EndLine: 4, Col: 17, EndCol: 27},
+		{Name: "Item", Line: 6, EndLine: 6, Col: 9, EndCol: 13},
+	}
+
+	if !reflect.DeepEqual(expected, vars) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, vars)
+	}
+}
+
+func TestAnomalousTypesAndConsts(t *testing.T) {
+	file, err := os.ReadFile("./testdata/types.rs")
+	if err != nil {
+		t.Fatalf("failed to open testdata/types.rs: %s", err.Error())
+	}
+	consts, err := anomalousConsts(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "default_port", Line: 2, EndLine: 2, Col: 7, EndCol: 19},
+		{Name: "Global_State", Line: 3, EndLine: 3, Col: 8, EndCol: 20},
+	}
+
+	if !reflect.DeepEqual(expected, consts) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, consts)
+	}
+
+	types, err := anomalousTypes(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected = []Ident{
+		{Name: "http_server", Line: 7, EndLine: 7, Col: 8, EndCol: 19},
+		{Name: "into_json", Line: 14, EndLine: 14, Col: 7, EndCol: 16},
+		{Name: "user_id", Line: 16, EndLine: 16, Col: 6, EndCol: 13},
+	}
+
+	if !reflect.DeepEqual(expected, types) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, types)
+	}
+
+	variants, err := anomalousVariants(file, Language.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected = []Ident{
+		{Name: "LIGHT_BLUE", Line: 11, EndLine: 11, Col: 5, EndCol: 15},
+	}
+
+	if !reflect.DeepEqual(expected, variants) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, variants)
+	}
+}
@@ -0,0 +1,20 @@
+mod parser;
+mod HttpClient;
+
+pub mod inner_Mod {}
+
+fn parse_config() {}
+
+fn parseArgs() {}
+
+trait Runner {
+    fn Run(&self);
+}
+
+impl Runner for Job {
+    fn Run(&self) {}
+}
+
+fn r#match() {}
+
+fn type_() {}
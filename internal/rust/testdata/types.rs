@@ -0,0 +1,18 @@
+const MAX_SIZE: usize = 64;
+const default_port: u16 = 8080;
+static Global_State: i32 = 0;
+
+struct HttpClient {}
+
+struct http_server {}
+
+enum Color {
+    DarkRed,
+    LIGHT_BLUE,
+}
+
+trait into_json {}
+
+type user_id = u64;
+
+const _: () = ();
@@ -0,0 +1,7 @@
+fn main(retryCount: u32, _unused: u8) {
+    let total = 0;
+    let mut runningTotal = 0;
+    let (first, secondItem) = (1, 2);
+    if let Some(Value) = lookup() {}
+    for Item in items {}
+}
@@ -0,0 +1,19 @@
+class HttpClient {}
+
+class http_server {}
+
+export abstract class base_handler {}
+
+interface UserProps {
+  name: string;
+}
+
+interface user_state {
+  loading: boolean;
+}
+
+type request_id = string;
+
+enum log_level {
+  Debug,
+}
@@ -0,0 +1,11 @@
+export function userCard() {
+  return <div />;
+}
+
+const Header = () => <h1 />;
+
+class user_list {
+  renderItem() {
+    return <li />;
+  }
+}
@@ -0,0 +1,18 @@
+export function UserCard(): JSX.Element {
+  return <div />;
+}
+
+function userList() {
+  return (
+    <ul>
+      <li />
+    </ul>
+  );
+}
+
+const emptyState = () => <></>;
+
+function formatName(name: string): string {
+  const render = () => <span>{name}</span>;
+  return name;
+}
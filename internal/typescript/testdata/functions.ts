@@ -0,0 +1,19 @@
+export function parseConfig(path: string): void {}
+
+function parse_args(): void {}
+
+const build_url = (host: string) => `https://${host}`;
+
+const handler = function () {};
+
+function* Numbers() {}
+
+class Client {
+  constructor() {}
+
+  send_request(): void {}
+
+  get isOpen(): boolean {
+    return true;
+  }
+}
@@ -0,0 +1,17 @@
+const MAX_RETRIES = 3;
+const maxTimeout = 30;
+export const default_name = "citk";
+const greeting = `hello ${name}`;
+const DefaultOptions = { retries: MAX_RETRIES };
+const api_client = createClient();
+
+let retry_count = 0;
+var userName = "";
+
+function main(): void {
+  const LOCAL_LIMIT = 10;
+  let attempts = 0;
+}
+
+const _ = require('lodash');
+let $ = jq;
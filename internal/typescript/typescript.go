@@ -0,0 +1,378 @@
+package typescript
+
+import (
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	ts "github.com/smacker/go-tree-sitter/typescript/typescript"
+	"github.com/tjgurwara99/citk/internal/annotation"
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+// Ident is an identifier found by one of the TypeScript and JavaScript
+// inspectors.
+type Ident = inspect.Ident
+
+// TypeScript describes TypeScript source files to the inspection engine.
+// TSX files are parsed with the TSX grammar.
+var TypeScript = inspect.Language{
+	Name:       "typescript",
+	Extensions: []string{".ts", ".tsx", ".mts", ".cts"},
+	Grammar:    ts.GetLanguage(),
+	Dialects: map[string]*sitter.Language{
+		".tsx": tsx.GetLanguage(),
+	},
+}
+
+// JavaScript describes JavaScript source files to the inspection engine.
+// The JavaScript grammar parses JSX as well.
+var JavaScript = inspect.Language{
+	Name:       "javascript",
+	Extensions: []string{".js", ".jsx", ".mjs", ".cjs"},
+	Grammar:    javascript.GetLanguage(),
+}
+
+var (
+	// Leading underscores and dollar signs are allowed by every style.
+	camelCaseRE  = regexp.MustCompile(`^[_$]*[a-z][a-zA-Z0-9]*$`)
+	pascalCaseRE = regexp.MustCompile(`^[_$]*[A-Z][a-zA-Z0-9]*$`)
+	upperCaseRE  = regexp.MustCompile(`^[_$]*[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// names made only of underscores and dollar signs, like the _ of lodash or
+// the $ of jQuery, have no case and pass
+func isCamelCase(ident string) bool {
+	return strings.Trim(ident, "_$") == "" || camelCaseRE.MatchString(ident)
+}
+
+func isPascalCase(ident string) bool {
+	return pascalCaseRE.MatchString(ident)
+}
+
+func isUpperCase(ident string) bool {
+	return upperCaseRE.MatchString(ident)
+}
+
+// functions captures the names of function declarations, methods and
+// variables initialised with a function.
+const functions = `[
+	(function_declaration name: (identifier) @func)
+	(generator_function_declaration name: (identifier) @func)
+	(method_definition name: (property_identifier) @func)
+	(variable_declarator
+		name: (identifier) @func
+		value: [(arrow_function) (function) (generator_function)])
+]`
+
+// function returns the function node named by name, a node captured by
+// the functions query.
+func function(name *sitter.Node) *sitter.Node {
+	if p := name.Parent(); p.Type() == "variable_declarator" {
+		return p.ChildByFieldName("value")
+	}
+	return name.Parent()
+}
+
+// isComponent reports whether name names a React function component, that
+// is a function declared at the top level of a module which returns JSX.
+// Nested functions returning JSX are render helpers.
+func isComponent(name *sitter.Node) bool {
+	decl := name.Parent()
+	switch decl.Type() {
+	case "method_definition":
+		return false
+	case "variable_declarator":
+		decl = decl.Parent()
+	}
+	if !isTopLevel(decl) {
+		return false
+	}
+	fn := function(name)
+	body := fn.ChildByFieldName("body")
+	if body == nil {
+		return false
+	}
+	if body.Type() != "statement_block" {
+		// arrow function with an expression body
+		return isJSX(body)
+	}
+	return returnsJSX(body)
+}
+
+// returnsJSX reports whether a return statement in n, outside of nested
+// functions, returns JSX.
+func returnsJSX(n *sitter.Node) bool {
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		child := n.NamedChild(i)
+		switch child.Type() {
+		case "function_declaration", "generator_function_declaration", "function",
+			"generator_function", "arrow_function", "class_declaration", "class":
+			continue
+		case "return_statement":
+			if child.NamedChildCount() > 0 && isJSX(child.NamedChild(0)) {
+				return true
+			}
+		}
+		if returnsJSX(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func isJSX(n *sitter.Node) bool {
+	for n.Type() == "parenthesized_expression" && n.NamedChildCount() > 0 {
+		n = n.NamedChild(0)
+	}
+	switch n.Type() {
+	case "jsx_element", "jsx_self_closing_element", "jsx_fragment":
+		return true
+	}
+	return false
+}
+
+// isTopLevel reports whether the declaration decl is at the top level of a
+// module, exported or not.
+func isTopLevel(decl *sitter.Node) bool {
+	p := decl.Parent()
+	if p != nil && p.Type() == "export_statement" {
+		p = p.Parent()
+	}
+	return p != nil && p.Type() == "program"
+}
+
+// isTopLevelConst reports whether declarator is part of a const declaration
+// at the top level of a module.
+func isTopLevelConst(declarator *sitter.Node) bool {
+	decl := declarator.Parent()
+	return decl.Type() == "lexical_declaration" && decl.Child(0).Type() == "const" && isTopLevel(decl)
+}
+
+// isPrimitive reports whether n is a literal of a primitive type.
+func isPrimitive(n *sitter.Node) bool {
+	switch n.Type() {
+	case "number", "string", "true", "false", "null", "regex":
+		return true
+	case "template_string":
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			if n.NamedChild(i).Type() == "template_substitution" {
+				return false
+			}
+		}
+		return true
+	case "unary_expression":
+		arg := n.ChildByFieldName("argument")
+		return arg != nil && arg.Type() == "number"
+	}
+	return false
+}
+
+// isConstant reports whether declarator declares a top-level const
+// primitive, which is named in UPPER_CASE.
+func isConstant(declarator *sitter.Node) bool {
+	value := declarator.ChildByFieldName("value")
+	return value != nil && isPrimitive(value) && isTopLevelConst(declarator)
+}
+
+func anomalousFunctions(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isCamelCase(ident)
+	}, opts)
+	return inspect.QueryNodes(src, grammar, functions, "", func(n *sitter.Node) bool {
+		return !isComponent(n) && check(n.Content(src))
+	})
+}
+
+func anomalousComponents(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isPascalCase(ident)
+	}, opts)
+	return inspect.QueryNodes(src, grammar, functions, "", func(n *sitter.Node) bool {
+		return isComponent(n) && check(n.Content(src))
+	})
+}
+
+func anomalousVariables(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterVariables := `(
+		(variable_declarator name: (identifier) @var)
+	)`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isCamelCase(ident)
+	}, opts)
+	// top-level constants often hold configuration objects, styled
+	// components or contexts
+	checkTopLevelConst := inspect.WithExceptions(func(ident string) bool {
+		return !isCamelCase(ident) && !isPascalCase(ident) && !isUpperCase(ident)
+	}, opts)
+	return inspect.QueryNodes(src, grammar, filterVariables, "", func(n *sitter.Node) bool {
+		declarator := n.Parent()
+		if value := declarator.ChildByFieldName("value"); value != nil {
+			switch value.Type() {
+			case "arrow_function", "function", "generator_function":
+				// checked by anomalousFunctions and anomalousComponents
+				return false
+			}
+		}
+		if isConstant(declarator) {
+			// checked by anomalousConstants
+			return false
+		}
+		if isTopLevelConst(declarator) {
+			return checkTopLevelConst(n.Content(src))
+		}
+		return check(n.Content(src))
+	})
+}
+
+func anomalousConstants(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterConstants := `(
+		(variable_declarator name: (identifier) @constant)
+	)`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isUpperCase(ident)
+	}, opts)
+	return inspect.QueryNodes(src, grammar, filterConstants, "", func(n *sitter.Node) bool {
+		return isConstant(n.Parent()) && check(n.Content(src))
+	})
+}
+
+// anomalousClasses returns an InspectFunc reporting the class names
+// captured by query that aren't PascalCase.
+func anomalousClasses(query string) inspect.InspectFunc {
+	return func(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+		check := inspect.WithExceptions(func(ident string) bool {
+			return !isPascalCase(ident)
+		}, opts)
+		return inspect.Query(src, grammar, query, "", check)
+	}
+}
+
+func anomalousTypes(src []byte, grammar *sitter.Language, opts inspect.Options) ([]Ident, error) {
+	filterTypes := `[
+		(interface_declaration name: (type_identifier) @type)
+		(type_alias_declaration name: (type_identifier) @type)
+		(enum_declaration name: (identifier) @type)
+	]`
+	check := inspect.WithExceptions(func(ident string) bool {
+		return !isPascalCase(ident)
+	}, opts)
+	return inspect.Query(src, grammar, filterTypes, "", check)
+}
+
+// namingRules returns the naming rules shared by TypeScript and JavaScript
+// for lang, with IDs starting with prefix. classes is the query capturing
+// class names in the grammars of lang.
+func namingRules(lang inspect.Language, prefix, classes string) []inspect.Rule {
+	return []inspect.Rule{
+		{
+			ID:          prefix + "-class-naming",
+			Language:    lang.Name,
+			Category:    "naming",
+			Title:       "Class name not in PascalCase",
+			Description: "Class names are written in PascalCase.",
+			Examples: []inspect.Example{{
+				Bad:  "class httpClient {}",
+				Good: "class HttpClient {}",
+			}},
+			Message:  "The declaration of the class %s is not in PascalCase.",
+			Severity: annotation.Error,
+			Options:  []inspect.Option{inspect.ExceptionsOption},
+			Inspect:  anomalousClasses(classes),
+		},
+		{
+			ID:          prefix + "-component-naming",
+			Language:    lang.Name,
+			Category:    "naming",
+			Title:       "React component name not in PascalCase",
+			Description: "Functions returning JSX are React components, which are written in PascalCase so JSX doesn't mistake them for HTML elements.",
+			Examples: []inspect.Example{{
+				Bad:  "function userCard() {\n  return <div />;\n}",
+				Good: "function UserCard() {\n  return <div />;\n}",
+			}},
+			Message:  "The declaration of the component %s is not in PascalCase.",
+			Severity: annotation.Error,
+			Options:  []inspect.Option{inspect.ExceptionsOption},
+			Inspect:  anomalousComponents,
+		},
+		{
+			ID:          prefix + "-constant-naming",
+			Language:    lang.Name,
+			Category:    "naming",
+			Title:       "Constant name not in UPPER_CASE",
+			Description: "Top-level const declarations of primitive values are written in UPPER_CASE.",
+			Examples: []inspect.Example{{
+				Bad:  "const maxRetries = 3;",
+				Good: "const MAX_RETRIES = 3;",
+			}},
+			Message:  "The declaration of the constant %s is not in UPPER_CASE.",
+			Severity: annotation.Error,
+			Options:  []inspect.Option{inspect.ExceptionsOption},
+			Inspect:  anomalousConstants,
+		},
+		{
+			ID:          prefix + "-function-naming",
+			Language:    lang.Name,
+			Category:    "naming",
+			Title:       "Function name not in camelCase",
+			Description: "Functions and methods, including variables holding a function, are written in camelCase.",
+			Examples: []inspect.Example{{
+				Bad:  "function parse_config(path) {}",
+				Good: "function parseConfig(path) {}",
+			}},
+			Message:  "The declaration of the function %s is not in camelCase.",
+			Severity: annotation.Error,
+			Options:  []inspect.Option{inspect.ExceptionsOption},
+			Inspect:  anomalousFunctions,
+		},
+		{
+			ID:          prefix + "-variable-naming",
+			Language:    lang.Name,
+			Category:    "naming",
+			Title:       "Variable name not in camelCase",
+			Description: "Variables are written in camelCase. Top-level constants may also be PascalCase or UPPER_CASE.",
+			Examples: []inspect.Example{{
+				Bad:  "let retry_count = 0;",
+				Good: "let retryCount = 0;",
+			}},
+			Message:  "The variable %s is not in camelCase.",
+			Severity: annotation.Error,
+			Options:  []inspect.Option{inspect.ExceptionsOption},
+			Inspect:  anomalousVariables,
+		},
+	}
+}
+
+// TypeScriptRules lists the naming conventions checked in TypeScript
+// source files.
+var TypeScriptRules = append(namingRules(TypeScript, "ts", `[
+	(class_declaration name: (type_identifier) @class)
+	(abstract_class_declaration name: (type_identifier) @class)
+]`), inspect.Rule{
+	ID:          "ts-type-naming",
+	Language:    TypeScript.Name,
+	Category:    "naming",
+	Title:       "Type name not in PascalCase",
+	Description: "Interfaces, type aliases and enums are written in PascalCase.",
+	Examples: []inspect.Example{{
+		Bad:  "interface user_props {\n  name: string;\n}",
+		Good: "interface UserProps {\n  name: string;\n}",
+	}},
+	Message:  "The declaration of the type %s is not in PascalCase.",
+	Severity: annotation.Error,
+	Options:  []inspect.Option{inspect.ExceptionsOption},
+	Inspect:  anomalousTypes,
+})
+
+// JavaScriptRules lists the naming conventions checked in JavaScript
+// source files.
+var JavaScriptRules = namingRules(JavaScript, "js", `(
+	(class_declaration name: (identifier) @class)
+)`)
+
+func init() {
+	inspect.MustRegister(TypeScriptRules...)
+	inspect.MustRegister(JavaScriptRules...)
+}
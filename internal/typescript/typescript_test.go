@@ -0,0 +1,153 @@
+package typescript
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/tjgurwara99/citk/internal/inspect"
+)
+
+func TestAnomalousFunctions(t *testing.T) {
+	file, err := os.ReadFile("./testdata/functions.ts")
+	if err != nil {
+		t.Fatalf("failed to open testdata/functions.ts: %s", err.Error())
+	}
+	funcs, err := anomalousFunctions(file, TypeScript.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "parse_args", Line: 3, EndLine: 3, Col: 10, EndCol: 20},
+		{Name: "build_url", Line: 5, EndLine: 5, Col: 7, EndCol: 16},
+		{Name: "Numbers", Line: 9, EndLine: 9, Col: 11, EndCol: 18},
+		{Name: "send_request", Line: 14, EndLine: 14, Col: 3, EndCol: 15},
+	}
+
+	if !reflect.DeepEqual(expected, funcs) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, funcs)
+	}
+}
+
+func TestAnomalousVariablesAndConstants(t *testing.T) {
+	file, err := os.ReadFile("./testdata/variables.ts")
+	if err != nil {
+		t.Fatalf("failed to open testdata/variables.ts: %s", err.Error())
+	}
+	vars, err := anomalousVariables(file, TypeScript.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "api_client", Line: 6, EndLine: 6, Col: 7, EndCol: 17},
+		{Name: "retry_count", Line: 8, EndLine: 8, Col: 5, EndCol: 16},
+		{Name: "LOCAL_LIMIT", Line: 12, EndLine: 12, Col: 9, EndCol: 20},
+	}
+
+	if !reflect.DeepEqual(expected, vars) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, vars)
+	}
+
+	consts, err := anomalousConstants(file, TypeScript.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected = []Ident{
+		{Name: "maxTimeout", Line: 2, EndLine: 2, Col: 7, EndCol: 17},
+		{Name: "default_name", Line: 3, EndLine: 3, Col: 14, EndCol: 26},
+	}
+
+	if !reflect.DeepEqual(expected, consts) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, consts)
+	}
+}
+
+func TestAnomalousClassesAndTypes(t *testing.T) {
+	file, err := os.ReadFile("./testdata/classes.ts")
+	if err != nil {
+		t.Fatalf("failed to open testdata/classes.ts: %s", err.Error())
+	}
+	rule, _ := inspect.Lookup("ts-class-naming")
+	classes, err := rule.Inspect(file, TypeScript.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected := []Ident{
+		{Name: "http_server", Line: 3, EndLine: 3, Col: 7, EndCol: 18},
+		{Name: "base_handler", Line: 5, EndLine: 5, Col: 23, EndCol: 35},
+	}
+
+	if !reflect.DeepEqual(expected, classes) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, classes)
+	}
+
+	types, err := anomalousTypes(file, TypeScript.Grammar, nil)
+	if err != nil {
+		t.Errorf("returned an error: %s", err)
+	}
+
+	expected = []Ident{
+		{Name: "user_state", Line: 11, EndLine: 11, Col: 11, EndCol: 21},
+		{Name: "request_id", Line: 15, EndLine: 15, Col: 6, EndCol: 16},
+		{Name: "log_level", Line: 17, EndLine: 17, Col: 6, EndCol: 15},
+	}
+
+	if !reflect.DeepEqual(expected, types) {
+		t.Errorf("expected and returned values do not match: expected %+v, returned %+v", expected, types)
+	}
+}
+
+func TestInspectFileComponents(t *testing.T) {
+	tests := []struct {
+		fileName string
+		lang     inspect.Language
+		rules    []inspect.Rule
+		expected []string
+	}{
+		{
+			fileName: "components.tsx",
+			lang:     TypeScript,
+			rules:    TypeScriptRules,
+			expected: []string{"ts-component-naming:5", "ts-component-naming:13"},
+		},
+		{
+			fileName: "components.jsx",
+			lang:     JavaScript,
+			rules:    JavaScriptRules,
+			expected: []string{"js-class-naming:7", "js-component-naming:1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.fileName, func(t *testing.T) {
+			file, err := os.ReadFile("./testdata/" + test.fileName)
+			if err != nil {
+				t.Fatalf("failed to open testdata/%s: %s", test.fileName, err.Error())
+			}
+			result, err := inspect.File(file, test.fileName, test.lang, test.rules)
+			if err != nil {
+				t.Fatalf("returned an error: %s", err)
+			}
+			var findings []string
+			for _, a := range result.Annotations {
+				findings = append(findings, a.RuleID+":"+strconv.Itoa(int(a.StartLine)))
+			}
+			if !reflect.DeepEqual(test.expected, findings) {
+				t.Errorf("expected and returned values do not match: expected %+v, returned %+v", test.expected, findings)
+			}
+		})
+	}
+}
+
+func TestGrammarFor(t *testing.T) {
+	if TypeScript.GrammarFor("src/app.tsx") != TypeScript.Dialects[".tsx"] {
+		t.Errorf("expected .tsx files to be parsed with the TSX grammar")
+	}
+	if TypeScript.GrammarFor("src/app.ts") != TypeScript.Grammar {
+		t.Errorf("expected .ts files to be parsed with the TypeScript grammar")
+	}
+}